@@ -0,0 +1,60 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+)
+
+// ResolveReferences of this StreamBackup.
+// The referenced Stream sets the stream name and, if not set, the domain of the backup.
+func (mg *StreamBackup) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.Stream,
+		Reference:    mg.Spec.ForProvider.StreamRef,
+		Selector:     mg.Spec.ForProvider.StreamSelector,
+		To:           reference.To{Managed: &streamv1alpha1.Stream{}, List: &streamv1alpha1.StreamList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Stream")
+	}
+	mg.Spec.ForProvider.Stream = rsp.ResolvedValue
+	mg.Spec.ForProvider.StreamRef = rsp.ResolvedReference
+
+	if mg.Spec.ForProvider.StreamRef == nil || mg.Spec.ForProvider.Domain != "" {
+		return nil
+	}
+
+	s := &streamv1alpha1.Stream{}
+	if err := c.Get(ctx, types.NamespacedName{Name: mg.Spec.ForProvider.StreamRef.Name}, s); err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Domain")
+	}
+	mg.Spec.ForProvider.Domain = s.Spec.ForProvider.Domain
+
+	return nil
+}
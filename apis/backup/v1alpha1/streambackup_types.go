@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+// StreamBackupParameters are the configurable fields of a stream backup.
+type StreamBackupParameters struct {
+	// Stream is the name of the Jetstream stream that is backed up.
+	// Either Stream, StreamRef or StreamSelector must be set.
+	// +kubebuilder:validation:Optional
+	Stream string `json:"stream,omitempty"`
+
+	// StreamRef references a Stream to set Stream and Domain of the backup.
+	// +kubebuilder:validation:Optional
+	StreamRef *xpv1.Reference `json:"streamRef,omitempty"`
+
+	// StreamSelector selects a Stream to set Stream and Domain of the backup.
+	// +kubebuilder:validation:Optional
+	StreamSelector *xpv1.Selector `json:"streamSelector,omitempty"`
+
+	// Domain is the Jetstream domain of the stream.
+	// Defaults to the domain of the referenced Stream if StreamRef or StreamSelector is set.
+	// +kubebuilder:validation:Optional
+	Domain string `json:"domain,omitempty"`
+
+	// Target is the location the snapshot of the stream is written to.
+	// +kubebuilder:validation:Required
+	Target apisv1alpha1.BackupTarget `json:"target"`
+}
+
+// StreamBackupObservation are the observable fields of a stream backup.
+type StreamBackupObservation struct {
+	// Stream is the name of the stream that was backed up.
+	Stream string `json:"stream,omitempty"`
+
+	// Key identifies the snapshot within the target.
+	Key string `json:"key,omitempty"`
+
+	// Location is the directory or URL the snapshot is stored at.
+	Location string `json:"location,omitempty"`
+
+	// Size is the size of the snapshot in bytes.
+	Size int64 `json:"size,omitempty"`
+
+	// CompletionTime is the time the snapshot was completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// A StreamBackupSpec defines the desired state of a stream backup.
+type StreamBackupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       StreamBackupParameters `json:"forProvider"`
+}
+
+// A StreamBackupStatus represents the observed state of a stream backup.
+type StreamBackupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          StreamBackupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+// +genclient
+// +genclient:nonNamespaced
+
+// A StreamBackup is a managed resource that represents a snapshot of the data,
+// the configuration and the consumers of a JetStream stream. The snapshot is
+// taken once; create a new StreamBackup to take another one.
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STREAM",type="string",JSONPath=".spec.forProvider.stream"
+// +kubebuilder:printcolumn:name="COMPLETED",type="date",JSONPath=".status.atProvider.completionTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",priority=1,JSONPath=".status.atProvider.location"
+// +kubebuilder:printcolumn:name="SIZE",type="string",priority=1,JSONPath=".status.atProvider.size"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,nats}
+type StreamBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StreamBackupSpec   `json:"spec"`
+	Status StreamBackupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StreamBackupList contains a list of StreamBackup
+type StreamBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StreamBackup `json:"items"`
+}
+
+// StreamBackup type metadata.
+var (
+	StreamBackupKind             = reflect.TypeOf(StreamBackup{}).Name()
+	StreamBackupGroupKind        = schema.GroupKind{Group: Group, Kind: StreamBackupKind}.String()
+	StreamBackupKindAPIVersion   = StreamBackupKind + "." + SchemeGroupVersion.String()
+	StreamBackupGroupVersionKind = SchemeGroupVersion.WithKind(StreamBackupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&StreamBackup{}, &StreamBackupList{})
+}
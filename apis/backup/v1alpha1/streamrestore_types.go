@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+// StreamRestoreParameters are the configurable fields of a stream restore.
+type StreamRestoreParameters struct {
+	// Stream is the name of the Jetstream stream that is restored. It must
+	// match the name of the stream in the snapshot, as JetStream does not
+	// support renaming streams on restore. Snapshots of other streams are
+	// rejected before an existing stream is replaced.
+	// Defaults to the stream of the referenced StreamBackup if BackupRef is set.
+	// +kubebuilder:validation:Optional
+	Stream string `json:"stream,omitempty"`
+
+	// Domain is the Jetstream domain the stream is restored into.
+	// Defaults to the domain of the referenced StreamBackup if BackupRef is set.
+	// +kubebuilder:validation:Optional
+	Domain string `json:"domain,omitempty"`
+
+	// BackupRef references the StreamBackup whose snapshot is restored.
+	// Either BackupRef or Source must be set.
+	// +kubebuilder:validation:Optional
+	BackupRef *xpv1.Reference `json:"backupRef,omitempty"`
+
+	// Source is the location of a snapshot that is not managed by a StreamBackup.
+	// +kubebuilder:validation:Optional
+	Source *RestoreSource `json:"source,omitempty"`
+
+	// ReplaceExisting deletes an existing stream of the same name before the
+	// snapshot is restored. Restoring into an existing stream fails otherwise.
+	// The snapshot is downloaded and validated before the stream is deleted,
+	// a restore that fails afterwards leaves no stream behind.
+	// +kubebuilder:validation:Optional
+	ReplaceExisting bool `json:"replaceExisting,omitempty"`
+}
+
+// RestoreSource is the location of a snapshot.
+type RestoreSource struct {
+	// Target is the location the snapshot was written to.
+	// +kubebuilder:validation:Required
+	Target apisv1alpha1.BackupTarget `json:"target"`
+
+	// Key identifies the snapshot within the target, e.g. <stream>/<backup>.
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// StreamRestoreObservation are the observable fields of a stream restore.
+type StreamRestoreObservation struct {
+	// Stream is the name of the restored stream.
+	Stream string `json:"stream,omitempty"`
+
+	// Domain is the domain of the restored stream.
+	Domain string `json:"domain,omitempty"`
+
+	// Location is the directory or URL the snapshot was restored from.
+	Location string `json:"location,omitempty"`
+
+	// RestoreTime is the time the snapshot was restored.
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+
+	// Messages is the number of messages currently stored in the restored stream.
+	Messages uint64 `json:"messages,omitempty"`
+
+	// Bytes is the number of bytes currently stored in the restored stream.
+	Bytes uint64 `json:"bytes,omitempty"`
+}
+
+// A StreamRestoreSpec defines the desired state of a stream restore.
+type StreamRestoreSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       StreamRestoreParameters `json:"forProvider"`
+}
+
+// A StreamRestoreStatus represents the observed state of a stream restore.
+type StreamRestoreStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          StreamRestoreObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+// +genclient
+// +genclient:nonNamespaced
+
+// A StreamRestore is a managed resource that restores a snapshot into a
+// JetStream stream. The snapshot is restored once; deleting the StreamRestore
+// does not delete the restored stream.
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STREAM",type="string",JSONPath=".status.atProvider.stream"
+// +kubebuilder:printcolumn:name="RESTORED",type="date",JSONPath=".status.atProvider.restoreTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",priority=1,JSONPath=".status.atProvider.location"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,nats}
+type StreamRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StreamRestoreSpec   `json:"spec"`
+	Status StreamRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StreamRestoreList contains a list of StreamRestore
+type StreamRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StreamRestore `json:"items"`
+}
+
+// StreamRestore type metadata.
+var (
+	StreamRestoreKind             = reflect.TypeOf(StreamRestore{}).Name()
+	StreamRestoreGroupKind        = schema.GroupKind{Group: Group, Kind: StreamRestoreKind}.String()
+	StreamRestoreKindAPIVersion   = StreamRestoreKind + "." + SchemeGroupVersion.String()
+	StreamRestoreGroupVersionKind = SchemeGroupVersion.WithKind(StreamRestoreKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&StreamRestore{}, &StreamRestoreList{})
+}
@@ -25,18 +25,47 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
 	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1/consumer"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
 )
 
 // ConsumerParameters are the configurable fields of a consumer.
 type ConsumerParameters struct {
 	// Stream is the name of the Jetstream stream the consumer is created for.
-	// +kubebuilder:validation:Required
-	Stream string `json:"stream"`
+	// Either Stream, StreamRef or StreamSelector must be set.
+	// +kubebuilder:validation:Optional
+	Stream string `json:"stream,omitempty"`
+
+	// StreamRef references a Stream to set Stream, Domain and APIPrefix of the consumer.
+	// +kubebuilder:validation:Optional
+	StreamRef *xpv1.Reference `json:"streamRef,omitempty"`
+
+	// StreamSelector selects a Stream to set Stream, Domain and APIPrefix of the consumer.
+	// +kubebuilder:validation:Optional
+	StreamSelector *xpv1.Selector `json:"streamSelector,omitempty"`
 
 	// Domain is the domain of the Jetstream stream the consumer is created for.
+	// Defaults to the domain of the referenced Stream if StreamRef or StreamSelector is set.
 	// +kubebuilder:validation:Optional
 	Domain string `json:"domain,omitempty"`
 
+	// APIPrefix is the prefix of the JetStream API the consumer is managed through,
+	// e.g. $JS.hub.API to manage the consumer in another account that exports its
+	// JetStream API. Cannot be combined with Domain. Defaults to the API prefix
+	// of the referenced Stream if StreamRef or StreamSelector is set.
+	// +kubebuilder:validation:Optional
+	APIPrefix string `json:"apiPrefix,omitempty"`
+
+	// UpdatePolicy defines how changes to immutable fields of the consumer are handled.
+	// Reject blocks the change and Recreate deletes and recreates the consumer.
+	// +kubebuilder:validation:Enum=Reject;Recreate
+	// +kubebuilder:default=Reject
+	// +kubebuilder:validation:Optional
+	UpdatePolicy string `json:"updatePolicy,omitempty"`
+
+	// Health configures when the consumer is reported as unhealthy.
+	// +kubebuilder:validation:Optional
+	Health *apisv1alpha1.ConsumerHealth `json:"health,omitempty"`
+
 	// Config is the consumer configuration.
 	// +kubebuilder:validation:Required
 	Config consumer.ConsumerConfig `json:"config"`
@@ -51,7 +80,15 @@ type ConsumerObservation struct {
 // A ConsumerSpec defines the desired state of a consumer.
 type ConsumerSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
-	ForProvider       ConsumerParameters `json:"forProvider"`
+
+	// ManagementPolicy specifies which operations the provider performs on the
+	// consumer. FullControl creates, updates and deletes it, ObserveOnly only reports
+	// the state of an existing consumer and OrphanOnDelete never deletes it.
+	// +kubebuilder:default=FullControl
+	// +optional
+	ManagementPolicy apisv1alpha1.ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	ForProvider ConsumerParameters `json:"forProvider"`
 }
 
 // A ConsumerStatus represents the observed state of a consumer.
@@ -70,6 +107,8 @@ type ConsumerStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="DOMAIN",type="string",JSONPath=".spec.forProvider.domain"
+// +kubebuilder:printcolumn:name="REPLICAS HEALTHY",type="string",JSONPath=".status.conditions[?(@.type=='ReplicasHealthy')].status"
+// +kubebuilder:printcolumn:name="BACKLOG OK",type="string",JSONPath=".status.conditions[?(@.type=='BacklogWithinLimits')].status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="PUSH CONSUMER",type="string",priority=1,JSONPath=".status.atProvider.state.pushBound"
 // +kubebuilder:printcolumn:name="STREAM",type="string",priority=1,JSONPath=".status.atProvider.state.streamName"
@@ -77,6 +116,7 @@ type ConsumerStatus struct {
 // +kubebuilder:printcolumn:name="REDELIVERERD",type="string",priority=1,JSONPath=".status.atProvider.state.numRedelivered"
 // +kubebuilder:printcolumn:name="ACK PENDING",type="string",priority=1,JSONPath=".status.atProvider.state.numAckPending"
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,nats}
 type Consumer struct {
 	metav1.TypeMeta   `json:",inline"`
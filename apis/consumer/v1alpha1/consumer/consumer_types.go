@@ -16,6 +16,10 @@ limitations under the License.
 
 package consumer
 
+import (
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
 // +kubebuilder:object:generate=true
 // ConsumerConfig will determine the properties for a JetStream consumer.
 // For more information see https://docs.nats.io/jetstream/concepts/consumers
@@ -184,11 +188,11 @@ type PullConsumerSpec struct {
 	// +kubebuilder:validation:Optional
 	MaxRequestBatch int `json:"maxBatch,omitempty"`
 
-	// MaxRequestMaxBytes defines the  maximum total bytes that can be requested in a given batch.
+	// MaxRequestMaxBytes defines the  maximum total bytes that can be requested in a given batch, e.g. 1048576, 1Mi or 1MB.
 	// When set with MaxRequestBatch, the batch size will be constrained by whichever limit is hit first.
 	// This is a pull consumer specific setting.
 	// +kubebuilder:validation:Optional
-	MaxRequestMaxBytes int `json:"maxBytes,omitempty"`
+	MaxRequestMaxBytes apisv1alpha1.ByteQuantity `json:"maxBytes,omitempty"`
 }
 
 // ConsumerInfo is the info from a JetStream consumer.
@@ -1,11 +1,14 @@
 package consumer
 
 import (
+	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/nats-io/nats.go"
 
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
 	"github.com/edgefarm/provider-nats/internal/convert"
 
 	errors "github.com/edgefarm/provider-nats/apis/consumer/v1alpha1/consumer/errors"
@@ -112,7 +115,14 @@ func convertPullConsumer(in *ConsumerConfig, out *nats.ConsumerConfig) error {
 			}
 			out.MaxRequestExpires = dur
 		}
-		out.MaxRequestMaxBytes = in.PullConsumer.MaxRequestMaxBytes
+		maxBytes, err := in.PullConsumer.MaxRequestMaxBytes.Value()
+		if err != nil {
+			return fmt.Errorf("maxBytes: %w", err)
+		}
+		if maxBytes > math.MaxInt {
+			return fmt.Errorf("maxBytes: %s exceeds the maximum request size", in.PullConsumer.MaxRequestMaxBytes)
+		}
+		out.MaxRequestMaxBytes = int(maxBytes)
 		if in.PullConsumer.MaxWaiting != nil {
 			out.MaxWaiting = *in.PullConsumer.MaxWaiting
 		} else {
@@ -189,3 +199,108 @@ func ConfigV1Alpha1ToNats(name string, config *ConsumerConfig) (*nats.ConsumerCo
 	}
 	return natsConfig, nil
 }
+
+func convertDeliverPolicyToV1Alpha1(delivery nats.DeliverPolicy) string {
+	switch delivery {
+	case nats.DeliverLastPolicy:
+		return "Last"
+	case nats.DeliverNewPolicy:
+		return "New"
+	case nats.DeliverByStartSequencePolicy:
+		return "ByStartSequence"
+	case nats.DeliverByStartTimePolicy:
+		return "ByStartTime"
+	case nats.DeliverLastPerSubjectPolicy:
+		return "LastPerSubject"
+	default:
+		return "All"
+	}
+}
+
+func convertAckPolicyToV1Alpha1(policy nats.AckPolicy) string {
+	switch policy {
+	case nats.AckNonePolicy:
+		return "None"
+	case nats.AckAllPolicy:
+		return "All"
+	default:
+		return "Explicit"
+	}
+}
+
+func convertReplayPolicyToV1Alpha1(replay nats.ReplayPolicy) string {
+	switch replay {
+	case nats.ReplayOriginalPolicy:
+		return "Original"
+	default:
+		return "Instant"
+	}
+}
+
+// durationToV1Alpha1 converts an optional duration where zero means unset.
+func durationToV1Alpha1(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// ConfigNatsToV1Alpha1 converts a NATS consumer configuration to a v1alpha1 ConsumerConfig.
+// It is the inverse of ConfigV1Alpha1ToNats. A consumer with a deliver subject
+// is converted to a push consumer, otherwise to a pull consumer.
+func ConfigNatsToV1Alpha1(config *nats.ConsumerConfig) (*ConsumerConfig, error) {
+	out := &ConsumerConfig{
+		Description:       config.Description,
+		DeliverPolicy:     convertDeliverPolicyToV1Alpha1(config.DeliverPolicy),
+		OptStartSeq:       config.OptStartSeq,
+		AckPolicy:         convertAckPolicyToV1Alpha1(config.AckPolicy),
+		AckWait:           config.AckWait.String(),
+		MaxDeliver:        config.MaxDeliver,
+		FilterSubject:     config.FilterSubject,
+		ReplayPolicy:      convertReplayPolicyToV1Alpha1(config.ReplayPolicy),
+		SampleFrequency:   config.SampleFrequency,
+		MaxAckPending:     config.MaxAckPending,
+		InactiveThreshold: durationToV1Alpha1(config.InactiveThreshold),
+		Replicas:          config.Replicas,
+		MemoryStorage:     config.MemoryStorage,
+	}
+
+	if config.OptStartTime != nil {
+		optStartTime, err := convert.TimeToRFC3339(config.OptStartTime)
+		if err != nil {
+			return nil, err
+		}
+		out.OptStartTime = optStartTime
+	}
+
+	if len(config.BackOff) > 0 {
+		backOff := []string{}
+		for _, b := range config.BackOff {
+			backOff = append(backOff, b.String())
+		}
+		out.BackOff = strings.Join(backOff, ",")
+	}
+
+	if config.DeliverSubject != "" {
+		out.PushConsumer = &PushConsumerSpec{
+			RateLimit:      config.RateLimit,
+			HeadersOnly:    config.HeadersOnly,
+			DeliverSubject: config.DeliverSubject,
+			DeliverGroup:   config.DeliverGroup,
+			FlowControl:    config.FlowControl,
+			IdleHeartbeat:  durationToV1Alpha1(config.Heartbeat),
+		}
+		return out, nil
+	}
+
+	maxWaiting := config.MaxWaiting
+	out.PullConsumer = &PullConsumerSpec{
+		MaxWaiting:        &maxWaiting,
+		MaxRequestExpires: durationToV1Alpha1(config.MaxRequestExpires),
+		MaxRequestBatch:   config.MaxRequestBatch,
+	}
+	if config.MaxRequestMaxBytes != 0 {
+		out.PullConsumer.MaxRequestMaxBytes = apisv1alpha1.NewByteQuantity(int64(config.MaxRequestMaxBytes))
+	}
+	return out, nil
+}
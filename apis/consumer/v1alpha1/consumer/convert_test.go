@@ -6,6 +6,8 @@ import (
 
 	"github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/assert"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
 )
 
 func TestConvertToNatsMinimal(t *testing.T) {
@@ -66,7 +68,7 @@ func TestConvertToNatsPull(t *testing.T) {
 			MaxWaiting:         func() *int { i := 100; return &i }(),
 			MaxRequestExpires:  "1m",
 			MaxRequestBatch:    100,
-			MaxRequestMaxBytes: 1024,
+			MaxRequestMaxBytes: apisv1alpha1.NewByteQuantity(1024),
 		},
 	}
 
@@ -152,3 +154,29 @@ func TestConvertToNatsPush(t *testing.T) {
 		return t
 	}())
 }
+
+func TestConvertToNatsPullByteQuantities(t *testing.T) {
+	assert := assert.New(t)
+
+	for quantity, bytes := range map[apisv1alpha1.ByteQuantity]int{
+		"1Ki":  1024,
+		"1KiB": 1024,
+		"1MB":  1000000,
+		"-1":   -1,
+	} {
+		natsConfig, err := ConfigV1Alpha1ToNats("mystream", &ConsumerConfig{
+			PullConsumer: &PullConsumerSpec{MaxRequestMaxBytes: quantity},
+		})
+		assert.Nil(err, "quantity %s", quantity)
+		assert.Equal(bytes, natsConfig.MaxRequestMaxBytes, "quantity %s", quantity)
+	}
+}
+
+func TestConvertToNatsPullInvalidByteQuantity(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := ConfigV1Alpha1ToNats("mystream", &ConsumerConfig{
+		PullConsumer: &PullConsumerSpec{MaxRequestMaxBytes: "lots"},
+	})
+	assert.NotNil(err)
+}
@@ -30,7 +30,7 @@ func (c *ConsumerConfig) SetDefaults(t ConsumerType) {
 		c.PullConsumer.MaxWaiting = func() *int { i := DefaultMaxWait; return &i }()
 		c.PullConsumer.MaxRequestExpires = ""
 		c.PullConsumer.MaxRequestBatch = 0
-		c.PullConsumer.MaxRequestMaxBytes = 0
+		c.PullConsumer.MaxRequestMaxBytes = ""
 	}
 
 }
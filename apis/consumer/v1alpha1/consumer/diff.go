@@ -0,0 +1,76 @@
+package consumer
+
+import (
+	"github.com/nats-io/nats.go"
+)
+
+// DiffNatsConfig returns the names of the fields of the desired consumer configuration
+// that differ from the observed one. Fields left unset in the desired configuration
+// that the server populates with defaults are not reported.
+func DiffNatsConfig(desired *nats.ConsumerConfig, observed *nats.ConsumerConfig) []string {
+	diff := []string{}
+	add := func(field string, differs bool) {
+		if differs {
+			diff = append(diff, field)
+		}
+	}
+
+	add("Description", desired.Description != observed.Description)
+	add("DeliverPolicy", desired.DeliverPolicy != observed.DeliverPolicy)
+	add("OptStartSeq", desired.OptStartSeq != observed.OptStartSeq)
+	add("OptStartTime", !equalTime(desired, observed))
+	add("AckPolicy", desired.AckPolicy != observed.AckPolicy)
+	add("AckWait", desired.AckWait != 0 && desired.AckWait != observed.AckWait)
+	add("MaxDeliver", !equalLimit(desired.MaxDeliver, observed.MaxDeliver))
+	add("BackOff", !equalBackOff(desired, observed))
+	add("FilterSubject", desired.FilterSubject != observed.FilterSubject)
+	add("ReplayPolicy", desired.ReplayPolicy != observed.ReplayPolicy)
+	add("RateLimit", desired.RateLimit != observed.RateLimit)
+	add("SampleFrequency", desired.SampleFrequency != observed.SampleFrequency)
+	add("MaxAckPending", desired.MaxAckPending != 0 && desired.MaxAckPending != observed.MaxAckPending)
+	add("FlowControl", desired.FlowControl != observed.FlowControl)
+	add("Heartbeat", desired.Heartbeat != observed.Heartbeat)
+	add("HeadersOnly", desired.HeadersOnly != observed.HeadersOnly)
+	add("DeliverSubject", desired.DeliverSubject != observed.DeliverSubject)
+	add("DeliverGroup", desired.DeliverGroup != observed.DeliverGroup)
+	add("InactiveThreshold", desired.InactiveThreshold != 0 && desired.InactiveThreshold != observed.InactiveThreshold)
+	add("Replicas", desired.Replicas != 0 && desired.Replicas != observed.Replicas)
+	add("MemoryStorage", desired.MemoryStorage != observed.MemoryStorage)
+
+	// Pull specific settings are meaningless for push consumers.
+	if desired.DeliverSubject == "" {
+		add("MaxWaiting", desired.MaxWaiting != 0 && desired.MaxWaiting != observed.MaxWaiting)
+		add("MaxRequestBatch", desired.MaxRequestBatch != observed.MaxRequestBatch)
+		add("MaxRequestExpires", desired.MaxRequestExpires != observed.MaxRequestExpires)
+		add("MaxRequestMaxBytes", desired.MaxRequestMaxBytes != observed.MaxRequestMaxBytes)
+	}
+
+	return diff
+}
+
+// equalLimit treats zero and negative limits as unlimited as the server does.
+func equalLimit(desired int, observed int) bool {
+	if desired <= 0 && observed <= 0 {
+		return true
+	}
+	return desired == observed
+}
+
+func equalTime(desired *nats.ConsumerConfig, observed *nats.ConsumerConfig) bool {
+	if desired.OptStartTime == nil || observed.OptStartTime == nil {
+		return desired.OptStartTime == nil && observed.OptStartTime == nil
+	}
+	return desired.OptStartTime.Equal(*observed.OptStartTime)
+}
+
+func equalBackOff(desired *nats.ConsumerConfig, observed *nats.ConsumerConfig) bool {
+	if len(desired.BackOff) != len(observed.BackOff) {
+		return false
+	}
+	for i := range desired.BackOff {
+		if desired.BackOff[i] != observed.BackOff[i] {
+			return false
+		}
+	}
+	return true
+}
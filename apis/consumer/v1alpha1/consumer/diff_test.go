@@ -0,0 +1,70 @@
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffNatsConfigServerDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := &nats.ConsumerConfig{
+		Durable:       "myconsumer",
+		Name:          "myconsumer",
+		DeliverPolicy: nats.DeliverAllPolicy,
+		AckPolicy:     nats.AckExplicitPolicy,
+		MaxDeliver:    -1,
+		MaxWaiting:    DefaultMaxWait,
+	}
+	observed := &nats.ConsumerConfig{
+		Durable:       "myconsumer",
+		Name:          "myconsumer",
+		DeliverPolicy: nats.DeliverAllPolicy,
+		AckPolicy:     nats.AckExplicitPolicy,
+		AckWait:       30 * time.Second,
+		MaxDeliver:    -1,
+		MaxWaiting:    DefaultMaxWait,
+		MaxAckPending: 1000,
+		Replicas:      1,
+	}
+
+	assert.Empty(DiffNatsConfig(desired, observed))
+}
+
+func TestDiffNatsConfigChangedFields(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := &nats.ConsumerConfig{
+		Durable:       "myconsumer",
+		AckWait:       time.Minute,
+		BackOff:       []time.Duration{time.Second, 2 * time.Second},
+		MaxAckPending: 10,
+	}
+	observed := &nats.ConsumerConfig{
+		Durable:       "myconsumer",
+		AckWait:       30 * time.Second,
+		BackOff:       []time.Duration{time.Second},
+		MaxAckPending: 1000,
+	}
+
+	assert.Equal([]string{"AckWait", "BackOff", "MaxAckPending"}, DiffNatsConfig(desired, observed))
+}
+
+func TestDiffNatsConfigPushIgnoresPullSettings(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := &nats.ConsumerConfig{
+		Durable:        "myconsumer",
+		DeliverSubject: "deliver.foo",
+		MaxWaiting:     DefaultMaxWait,
+	}
+	observed := &nats.ConsumerConfig{
+		Durable:        "myconsumer",
+		DeliverSubject: "deliver.foo",
+	}
+
+	assert.Empty(DiffNatsConfig(desired, observed))
+}
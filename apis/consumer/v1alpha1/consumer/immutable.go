@@ -0,0 +1,30 @@
+package consumer
+
+import (
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// UpdatePolicyReject blocks changes to immutable fields of a consumer.
+	UpdatePolicyReject = "Reject"
+	// UpdatePolicyRecreate deletes and recreates a consumer when immutable fields change.
+	UpdatePolicyRecreate = "Recreate"
+)
+
+// ImmutableDiff returns the names of the fields of the desired consumer configuration
+// that differ from the observed one and cannot be changed by updating the consumer.
+// Switching between a push and a pull consumer is reported as DeliverSubject.
+func ImmutableDiff(desired *nats.ConsumerConfig, observed *nats.ConsumerConfig) []string {
+	diff := []string{}
+	add := func(field string, differs bool) {
+		if differs {
+			diff = append(diff, field)
+		}
+	}
+
+	add("DeliverPolicy", desired.DeliverPolicy != observed.DeliverPolicy)
+	add("AckPolicy", desired.AckPolicy != observed.AckPolicy)
+	add("DeliverSubject", (desired.DeliverSubject == "") != (observed.DeliverSubject == ""))
+
+	return diff
+}
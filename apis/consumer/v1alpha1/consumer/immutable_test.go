@@ -0,0 +1,38 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImmutableDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	observed := &nats.ConsumerConfig{
+		Durable:       "myconsumer",
+		DeliverPolicy: nats.DeliverAllPolicy,
+		AckPolicy:     nats.AckExplicitPolicy,
+		MaxDeliver:    5,
+	}
+
+	desired := *observed
+	desired.MaxDeliver = 10
+	assert.Empty(ImmutableDiff(&desired, observed))
+
+	desired = *observed
+	desired.DeliverPolicy = nats.DeliverNewPolicy
+	desired.AckPolicy = nats.AckNonePolicy
+	assert.Equal([]string{"DeliverPolicy", "AckPolicy"}, ImmutableDiff(&desired, observed))
+
+	desired = *observed
+	desired.DeliverSubject = "deliver.foo"
+	assert.Equal([]string{"DeliverSubject"}, ImmutableDiff(&desired, observed))
+
+	pushObserved := *observed
+	pushObserved.DeliverSubject = "deliver.foo"
+	desired = pushObserved
+	desired.DeliverSubject = "deliver.bar"
+	assert.Empty(ImmutableDiff(&desired, &pushObserved))
+}
@@ -0,0 +1,47 @@
+package consumer
+
+import (
+	"github.com/nats-io/nats.go"
+)
+
+// LateInitialize sets the fields of the consumer configuration that were left
+// unset and are populated with defaults by the server to the observed values.
+// It returns true if any field was set.
+func LateInitialize(in *ConsumerConfig, observed *nats.ConsumerConfig) (bool, error) {
+	from, err := ConfigNatsToV1Alpha1(observed)
+	if err != nil {
+		return false, err
+	}
+
+	li := false
+	lateInit(&in.DeliverPolicy, from.DeliverPolicy, &li)
+	lateInit(&in.AckPolicy, from.AckPolicy, &li)
+	lateInit(&in.AckWait, from.AckWait, &li)
+	lateInit(&in.MaxDeliver, from.MaxDeliver, &li)
+	lateInit(&in.ReplayPolicy, from.ReplayPolicy, &li)
+	lateInit(&in.MaxAckPending, from.MaxAckPending, &li)
+	lateInit(&in.InactiveThreshold, from.InactiveThreshold, &li)
+
+	// Consumers without a push configuration are pull consumers.
+	if in.PushConsumer == nil && from.PullConsumer != nil {
+		if in.PullConsumer == nil {
+			in.PullConsumer = &PullConsumerSpec{}
+			li = true
+		}
+		if in.PullConsumer.MaxWaiting == nil {
+			in.PullConsumer.MaxWaiting = from.PullConsumer.MaxWaiting
+			li = true
+		}
+	}
+
+	return li, nil
+}
+
+// lateInit sets in to from if in has its zero value.
+func lateInit[T comparable](in *T, from T, li *bool) {
+	var zero T
+	if *in == zero && from != zero {
+		*in = from
+		*li = true
+	}
+}
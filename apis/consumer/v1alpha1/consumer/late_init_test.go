@@ -0,0 +1,72 @@
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertNatsToV1Alpha1RoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	natsConfig := &nats.ConsumerConfig{
+		Durable:        "myconsumer",
+		Name:           "myconsumer",
+		DeliverPolicy:  nats.DeliverNewPolicy,
+		AckPolicy:      nats.AckAllPolicy,
+		AckWait:        30 * time.Second,
+		MaxDeliver:     -1,
+		BackOff:        []time.Duration{time.Second, 2 * time.Second},
+		ReplayPolicy:   nats.ReplayOriginalPolicy,
+		MaxAckPending:  1000,
+		DeliverSubject: "deliver.foo",
+		Heartbeat:      5 * time.Second,
+		FlowControl:    true,
+	}
+
+	config, err := ConfigNatsToV1Alpha1(natsConfig)
+	assert.Nil(err)
+	assert.Equal("New", config.DeliverPolicy)
+	assert.Equal("All", config.AckPolicy)
+	assert.Equal("Original", config.ReplayPolicy)
+	assert.Equal("1s,2s", config.BackOff)
+	assert.Equal("", config.InactiveThreshold)
+	assert.Nil(config.PullConsumer)
+	assert.Equal("5s", config.PushConsumer.IdleHeartbeat)
+
+	converted, err := ConfigV1Alpha1ToNats("myconsumer", config)
+	assert.Nil(err)
+	assert.Empty(DiffNatsConfig(converted, natsConfig))
+}
+
+func TestLateInitialize(t *testing.T) {
+	assert := assert.New(t)
+
+	observed := &nats.ConsumerConfig{
+		Durable:           "myconsumer",
+		DeliverPolicy:     nats.DeliverAllPolicy,
+		AckPolicy:         nats.AckExplicitPolicy,
+		AckWait:           30 * time.Second,
+		MaxDeliver:        -1,
+		MaxAckPending:     1000,
+		InactiveThreshold: 5 * time.Minute,
+		MaxWaiting:        DefaultMaxWait,
+	}
+
+	config := &ConsumerConfig{
+		AckPolicy: "Explicit",
+	}
+	li, err := LateInitialize(config, observed)
+	assert.Nil(err)
+	assert.True(li)
+	assert.Equal("30s", config.AckWait)
+	assert.Equal(1000, config.MaxAckPending)
+	assert.Equal("5m0s", config.InactiveThreshold)
+	assert.Equal(DefaultMaxWait, *config.PullConsumer.MaxWaiting)
+
+	li, err = LateInitialize(config, observed)
+	assert.Nil(err)
+	assert.False(li)
+}
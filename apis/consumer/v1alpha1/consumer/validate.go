@@ -0,0 +1,45 @@
+package consumer
+
+import (
+	"math"
+
+	"github.com/nats-io/nats.go"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns the errors of a consumer configuration that the NATS server
+// would reject. It checks that the configuration converts to a NATS consumer
+// configuration and applies the cross-field rules of JetStream.
+func Validate(config *ConsumerConfig, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if config.PullConsumer != nil {
+		errs = append(errs, config.PullConsumer.MaxRequestMaxBytes.Validate(path.Child("pull", "maxBytes"), math.MaxInt)...)
+		if len(errs) > 0 {
+			return errs
+		}
+	}
+
+	converted, err := ConfigV1Alpha1ToNats("", config)
+	if err != nil {
+		return append(errs, field.Invalid(path, field.OmitValueType{}, err.Error()))
+	}
+
+	byStartTime := converted.DeliverPolicy == nats.DeliverByStartTimePolicy
+	switch {
+	case converted.OptStartTime != nil && !byStartTime:
+		errs = append(errs, field.Invalid(path.Child("optStartTime"), config.OptStartTime, "requires deliverPolicy ByStartTime"))
+	case converted.OptStartTime == nil && byStartTime:
+		errs = append(errs, field.Required(path.Child("optStartTime"), "must be set for deliverPolicy ByStartTime"))
+	}
+
+	byStartSequence := converted.DeliverPolicy == nats.DeliverByStartSequencePolicy
+	switch {
+	case converted.OptStartSeq != 0 && !byStartSequence:
+		errs = append(errs, field.Invalid(path.Child("optStartSeq"), config.OptStartSeq, "requires deliverPolicy ByStartSequence"))
+	case converted.OptStartSeq == 0 && byStartSequence:
+		errs = append(errs, field.Required(path.Child("optStartSeq"), "must be set for deliverPolicy ByStartSequence"))
+	}
+
+	return errs
+}
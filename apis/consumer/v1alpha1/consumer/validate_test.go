@@ -0,0 +1,74 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1/consumer/errors"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ConsumerConfig
+		fields []string
+	}{
+		{
+			name:   "valid",
+			config: ConsumerConfig{DeliverPolicy: "ByStartTime", OptStartTime: "2023-01-09T14:48:32Z", PullConsumer: &PullConsumerSpec{}},
+		},
+		{
+			name:   "unparsable AckWait",
+			config: ConsumerConfig{AckWait: "thirty seconds"},
+			fields: []string{"config"},
+		},
+		{
+			name:   "pull MaxBytes",
+			config: ConsumerConfig{PullConsumer: &PullConsumerSpec{MaxRequestMaxBytes: "1Mi"}},
+		},
+		{
+			name:   "unparsable pull MaxBytes",
+			config: ConsumerConfig{PullConsumer: &PullConsumerSpec{MaxRequestMaxBytes: "lots"}},
+			fields: []string{"config.pull.maxBytes"},
+		},
+		{
+			name:   "pull MaxBytes below -1",
+			config: ConsumerConfig{PullConsumer: &PullConsumerSpec{MaxRequestMaxBytes: "-2"}},
+			fields: []string{"config.pull.maxBytes"},
+		},
+		{
+			name:   "OptStartTime without DeliverPolicy ByStartTime",
+			config: ConsumerConfig{DeliverPolicy: "All", OptStartTime: "2023-01-09T14:48:32Z"},
+			fields: []string{"config.optStartTime"},
+		},
+		{
+			name:   "DeliverPolicy ByStartTime without OptStartTime",
+			config: ConsumerConfig{DeliverPolicy: "ByStartTime"},
+			fields: []string{"config.optStartTime"},
+		},
+		{
+			name:   "OptStartSeq without DeliverPolicy ByStartSequence",
+			config: ConsumerConfig{DeliverPolicy: "All", OptStartSeq: 10},
+			fields: []string{"config.optStartSeq"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(&tt.config, field.NewPath("config"))
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestValidatePushAndPull(t *testing.T) {
+	config := &ConsumerConfig{PushConsumer: &PushConsumerSpec{DeliverSubject: "foo"}, PullConsumer: &PullConsumerSpec{}}
+	errs := Validate(config, field.NewPath("config"))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, errors.PushAndPullConsumerError.Error(), errs[0].Detail)
+}
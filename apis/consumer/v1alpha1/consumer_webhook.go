@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1/consumer"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-nats-crossplane-io-v1alpha1-consumer,mutating=false,failurePolicy=fail,groups=nats.crossplane.io,resources=consumers,versions=v1alpha1,name=consumers.nats.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Consumer{}
+
+// SetupWebhookWithManager registers the validating webhook of Consumers and
+// the conversion webhook between their versions.
+func (mg *Consumer) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(mg).Complete()
+}
+
+// ValidateCreate rejects Consumers whose configuration the NATS server would reject.
+func (mg *Consumer) ValidateCreate() error {
+	return mg.validate()
+}
+
+// ValidateUpdate rejects changes to the configuration, the API prefix or the
+// stream of a Consumer that the NATS server would reject. Updates that leave all
+// of them unchanged are admitted, so that Consumers created before the webhook
+// existed can still be reconciled and deleted.
+func (mg *Consumer) ValidateUpdate(old runtime.Object) error {
+	if o, ok := old.(*Consumer); ok && reflect.DeepEqual(o.Spec.ForProvider.Config, mg.Spec.ForProvider.Config) &&
+		o.Spec.ForProvider.APIPrefix == mg.Spec.ForProvider.APIPrefix && o.hasStream() == mg.hasStream() {
+		return nil
+	}
+	return mg.validate()
+}
+
+// ValidateDelete admits all deletions.
+func (mg *Consumer) ValidateDelete() error {
+	return nil
+}
+
+func (mg *Consumer) validate() error {
+	errs := consumer.Validate(&mg.Spec.ForProvider.Config, field.NewPath("spec", "forProvider", "config"))
+	if !mg.hasStream() {
+		errs = append(errs, field.Required(field.NewPath("spec", "forProvider", "stream"), "one of stream, streamRef or streamSelector must be set"))
+	}
+	if mg.Spec.ForProvider.Domain != "" && mg.Spec.ForProvider.APIPrefix != "" {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "forProvider", "apiPrefix"), "must not be set together with domain"))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return kerrors.NewInvalid(ConsumerGroupVersionKind.GroupKind(), mg.GetName(), errs)
+}
+
+// hasStream returns true if the stream of the Consumer is set or selected.
+func (mg *Consumer) hasStream() bool {
+	p := mg.Spec.ForProvider
+	return p.Stream != "" || p.StreamRef != nil || p.StreamSelector != nil
+}
@@ -0,0 +1,30 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+func TestValidateStream(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, p := range []ConsumerParameters{
+		{Stream: "orders"},
+		{StreamRef: &xpv1.Reference{Name: "orders"}},
+		{StreamSelector: &xpv1.Selector{MatchLabels: map[string]string{"app": "orders"}}},
+	} {
+		assert.Nil((&Consumer{Spec: ConsumerSpec{ForProvider: p}}).ValidateCreate())
+	}
+
+	missing := &Consumer{}
+	assert.True(kerrors.IsInvalid(missing.ValidateCreate()))
+
+	// Clearing the stream of an existing Consumer is rejected, while Consumers
+	// that never had one can still be updated.
+	assert.True(kerrors.IsInvalid(missing.ValidateUpdate(&Consumer{Spec: ConsumerSpec{ForProvider: ConsumerParameters{Stream: "orders"}}})))
+	assert.Nil(missing.ValidateUpdate(&Consumer{}))
+}
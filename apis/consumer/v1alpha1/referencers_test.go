@@ -0,0 +1,136 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+)
+
+// newStream returns a Stream of the NATS stream "orders" in a domain or
+// through an API prefix.
+func newStream(name string, domain string, apiPrefix string) *streamv1alpha1.Stream {
+	s := &streamv1alpha1.Stream{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"app": name}}}
+	s.Spec.ForProvider.Domain = domain
+	s.Spec.ForProvider.APIPrefix = apiPrefix
+	meta.SetExternalName(s, "orders")
+	return s
+}
+
+// streamClient returns a kube client that gets and lists the supplied Streams.
+func streamClient(streams ...*streamv1alpha1.Stream) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			for _, s := range streams {
+				if s.GetName() == key.Name {
+					s.DeepCopyInto(obj.(*streamv1alpha1.Stream))
+					return nil
+				}
+			}
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "streams"}, key.Name)
+		},
+		MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			o := &client.ListOptions{}
+			o.ApplyOptions(opts)
+			l := obj.(*streamv1alpha1.StreamList)
+			for _, s := range streams {
+				if o.LabelSelector == nil || o.LabelSelector.Matches(labels.Set(s.GetLabels())) {
+					l.Items = append(l.Items, *s)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestResolveReferences(t *testing.T) {
+	kube := streamClient(
+		newStream("orders-hub", "hub", ""),
+		newStream("orders-prefixed", "", "$JS.hub.API"),
+	)
+
+	tests := []struct {
+		name      string
+		params    ConsumerParameters
+		stream    string
+		domain    string
+		apiPrefix string
+		ref       string
+		err       bool
+	}{
+		{
+			name:   "stream without reference",
+			params: ConsumerParameters{Stream: "invoices"},
+			stream: "invoices",
+		},
+		{
+			name:   "reference inherits domain",
+			params: ConsumerParameters{StreamRef: &xpv1.Reference{Name: "orders-hub"}},
+			stream: "orders",
+			domain: "hub",
+			ref:    "orders-hub",
+		},
+		{
+			name:      "reference inherits API prefix",
+			params:    ConsumerParameters{StreamRef: &xpv1.Reference{Name: "orders-prefixed"}},
+			stream:    "orders",
+			apiPrefix: "$JS.hub.API",
+			ref:       "orders-prefixed",
+		},
+		{
+			name:   "reference keeps domain",
+			params: ConsumerParameters{StreamRef: &xpv1.Reference{Name: "orders-prefixed"}, Domain: "leaf"},
+			stream: "orders",
+			domain: "leaf",
+			ref:    "orders-prefixed",
+		},
+		{
+			name:      "reference keeps API prefix",
+			params:    ConsumerParameters{StreamRef: &xpv1.Reference{Name: "orders-hub"}, APIPrefix: "$JS.leaf.API"},
+			stream:    "orders",
+			apiPrefix: "$JS.leaf.API",
+			ref:       "orders-hub",
+		},
+		{
+			name:   "selector",
+			params: ConsumerParameters{StreamSelector: &xpv1.Selector{MatchLabels: map[string]string{"app": "orders-hub"}}},
+			stream: "orders",
+			domain: "hub",
+			ref:    "orders-hub",
+		},
+		{
+			name:   "missing reference",
+			params: ConsumerParameters{StreamRef: &xpv1.Reference{Name: "missing"}},
+			err:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := &Consumer{Spec: ConsumerSpec{ForProvider: tt.params}}
+			err := mg.ResolveReferences(context.Background(), kube)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			p := mg.Spec.ForProvider
+			assert.Equal(t, tt.stream, p.Stream)
+			assert.Equal(t, tt.domain, p.Domain)
+			assert.Equal(t, tt.apiPrefix, p.APIPrefix)
+			if tt.ref != "" {
+				assert.Equal(t, tt.ref, p.StreamRef.Name)
+			}
+		})
+	}
+}
@@ -22,7 +22,9 @@ limitations under the License.
 package v1alpha1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -103,6 +105,21 @@ func (in *ConsumerObservation) DeepCopy() *ConsumerObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConsumerParameters) DeepCopyInto(out *ConsumerParameters) {
 	*out = *in
+	if in.StreamRef != nil {
+		in, out := &in.StreamRef, &out.StreamRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StreamSelector != nil {
+		in, out := &in.StreamSelector, &out.StreamSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(apisv1alpha1.ConsumerHealth)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Config.DeepCopyInto(&out.Config)
 }
 
@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/edgefarm/provider-nats/apis/consumer/v1beta1/consumer"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+// ConsumerParameters are the configurable fields of a consumer.
+type ConsumerParameters struct {
+	// Stream is the name of the Jetstream stream the consumer is created for.
+	// Either Stream, StreamRef or StreamSelector must be set.
+	// +kubebuilder:validation:Optional
+	Stream string `json:"stream,omitempty"`
+
+	// StreamRef references a Stream to set Stream, Domain and APIPrefix of the consumer.
+	// +kubebuilder:validation:Optional
+	StreamRef *xpv1.Reference `json:"streamRef,omitempty"`
+
+	// StreamSelector selects a Stream to set Stream, Domain and APIPrefix of the consumer.
+	// +kubebuilder:validation:Optional
+	StreamSelector *xpv1.Selector `json:"streamSelector,omitempty"`
+
+	// Domain is the domain of the Jetstream stream the consumer is created for.
+	// Defaults to the domain of the referenced Stream if StreamRef or StreamSelector is set.
+	// +kubebuilder:validation:Optional
+	Domain string `json:"domain,omitempty"`
+
+	// APIPrefix is the prefix of the JetStream API the consumer is managed through,
+	// e.g. $JS.hub.API to manage the consumer in another account that exports its
+	// JetStream API. Cannot be combined with Domain. Defaults to the API prefix
+	// of the referenced Stream if StreamRef or StreamSelector is set.
+	// +kubebuilder:validation:Optional
+	APIPrefix string `json:"apiPrefix,omitempty"`
+
+	// UpdatePolicy defines how changes to immutable fields of the consumer are handled.
+	// Reject blocks the change and Recreate deletes and recreates the consumer.
+	// +kubebuilder:validation:Enum=Reject;Recreate
+	// +kubebuilder:default=Reject
+	// +kubebuilder:validation:Optional
+	UpdatePolicy string `json:"updatePolicy,omitempty"`
+
+	// Health configures when the consumer is reported as unhealthy.
+	// +kubebuilder:validation:Optional
+	Health *apisv1alpha1.ConsumerHealth `json:"health,omitempty"`
+
+	// Config is the consumer configuration.
+	// +kubebuilder:validation:Required
+	Config consumer.ConsumerConfig `json:"config"`
+}
+
+// ConsumerObservation are the observable fields of a consumer.
+type ConsumerObservation struct {
+	// State is the current state of the consumer
+	State consumer.ConsumerObservationState `json:"state,omitempty"`
+}
+
+// A ConsumerSpec defines the desired state of a consumer.
+type ConsumerSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	// ManagementPolicy specifies which operations the provider performs on the
+	// consumer. FullControl creates, updates and deletes it, ObserveOnly only reports
+	// the state of an existing consumer and OrphanOnDelete never deletes it.
+	// +kubebuilder:default=FullControl
+	// +optional
+	ManagementPolicy apisv1alpha1.ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	ForProvider ConsumerParameters `json:"forProvider"`
+}
+
+// A ConsumerStatus represents the observed state of a consumer.
+type ConsumerStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ConsumerObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+
+// A Consumer is a JetStream consumer.
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DOMAIN",type="string",JSONPath=".spec.forProvider.domain"
+// +kubebuilder:printcolumn:name="REPLICAS HEALTHY",type="string",JSONPath=".status.conditions[?(@.type=='ReplicasHealthy')].status"
+// +kubebuilder:printcolumn:name="BACKLOG OK",type="string",JSONPath=".status.conditions[?(@.type=='BacklogWithinLimits')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="PUSH CONSUMER",type="string",priority=1,JSONPath=".status.atProvider.state.pushBound"
+// +kubebuilder:printcolumn:name="STREAM",type="string",priority=1,JSONPath=".status.atProvider.state.streamName"
+// +kubebuilder:printcolumn:name="UNPROCESSED",type="string",priority=1,JSONPath=".status.atProvider.state.numPending"
+// +kubebuilder:printcolumn:name="REDELIVERERD",type="string",priority=1,JSONPath=".status.atProvider.state.numRedelivered"
+// +kubebuilder:printcolumn:name="ACK PENDING",type="string",priority=1,JSONPath=".status.atProvider.state.numAckPending"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,nats}
+type Consumer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConsumerSpec   `json:"spec"`
+	Status ConsumerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConsumerList contains a list of consumer
+type ConsumerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Consumer `json:"items"`
+}
+
+// Consumer type metadata.
+var (
+	ConsumerKind             = reflect.TypeOf(Consumer{}).Name()
+	ConsumerGroupKind        = schema.GroupKind{Group: Group, Kind: ConsumerKind}.String()
+	ConsumerKindAPIVersion   = ConsumerKind + "." + SchemeGroupVersion.String()
+	ConsumerGroupVersionKind = SchemeGroupVersion.WithKind(ConsumerKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Consumer{}, &ConsumerList{})
+}
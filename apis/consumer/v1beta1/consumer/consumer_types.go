@@ -0,0 +1,223 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumer
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+// +kubebuilder:object:generate=true
+// ConsumerConfig will determine the properties for a JetStream consumer.
+// For more information see https://docs.nats.io/jetstream/concepts/consumers
+type ConsumerConfig struct {
+	// Description is a human readable description of the consumer.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// DeliverPolicy defines the point in the stream to receive messages from, either All, Last, New, ByStartSequence, ByStartTime, or LastPerSubject.
+	// +kubebuilder:validation:Enum=All;Last;New;ByStartSequence;ByStartTime;LastPerSubject
+	// +kubebuilder:default=All
+	// +kubebuilder:validation:Required
+	DeliverPolicy string `json:"deliverPolicy"`
+
+	// OptStartSeq is an optional start sequence number and is used with the DeliverByStartSequence deliver policy.
+	// +kubebuilder:validation:Optional
+	OptStartSeq uint64 `json:"optStartSeq,omitempty"`
+
+	// OptStartTime is an optional start time and is used with the DeliverByStartTime deliver policy.
+	// +kubebuilder:validation:Optional
+	OptStartTime *metav1.Time `json:"optStartTime,omitempty"`
+
+	// AckPolicy describes the requirement of client acknowledgements, either Explicit, None, or All.
+	// +kubebuilder:validation:Enum=Explicit;None;All
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default=Explicit
+	AckPolicy string `json:"ackPolicy"`
+
+	// AckWait is the duration that the server will wait for an ack for any individual message once it has been delivered to a consumer, e.g. 30s.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="30s"
+	AckWait *metav1.Duration `json:"ackWait,omitempty"`
+
+	// MaxDeliver is the maximum number of times a specific message delivery will be attempted.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=-1
+	MaxDeliver int `json:"maxDeliver,omitempty"`
+
+	// BackOff is a list of durations that represent the time to delay based on delivery count, e.g. [1s, 2s, 5s].
+	// +kubebuilder:validation:Optional
+	BackOff []metav1.Duration `json:"backoff,omitempty"`
+
+	// FilterSubject defines an overlapping subject with the subjects bound to the stream which will filter the set of messages received by the consumer.
+	// +kubebuilder:validation:Optional
+	FilterSubject string `json:"filterSubject,omitempty"`
+
+	// ReplayPolicy is used to define the mode of message replay, either Instant or Original.
+	// +kubebuilder:validation:Enum=Instant;Original
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default=Instant
+	ReplayPolicy string `json:"replayPolicy"`
+
+	// SampleFrequency sets the percentage of acknowledgements that should be sampled for observability.
+	// +kubebuilder:validation:Pattern="^([1-9][0-9]?|100)%?$"
+	// +kubebuilder:validation:Optional
+	SampleFrequency string `json:"sampleFreq,omitempty"`
+
+	// MaxAckPending sets the number of outstanding acks that are allowed before message delivery is halted.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1000
+	MaxAckPending int `json:"maxAckPending,omitempty"`
+
+	// InactiveThreshold defines the duration that instructs the server to cleanup consumers that are inactive for that long, e.g. 1h.
+	// +kubebuilder:validation:Optional
+	InactiveThreshold *metav1.Duration `json:"inactiveThreshold,omitempty"`
+
+	// Replicas sets the number of replicas for the consumer's state.
+	// By default, when the value is set to zero, consumers inherit the number of replicas from the stream.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default=0
+	Replicas int `json:"numReplicas"`
+
+	// MemoryStorage if set, forces the consumer state to be kept in memory rather than inherit the storage type of the stream.
+	// +kubebuilder:validation:Optional
+	MemoryStorage bool `json:"memStorage,omitempty"`
+
+	// PullConsumer defines the pull-based consumer configuration.
+	// +kubebuilder:validation:Optional
+	PullConsumer *PullConsumerSpec `json:"pull,omitempty"`
+
+	// PushConsumer defines the push-based consumer configuration.
+	// +kubebuilder:validation:Optional
+	PushConsumer *PushConsumerSpec `json:"push,omitempty"`
+}
+
+// PushConsumerSpec defines the push-based consumer configuration.
+// For more information, see https://docs.nats.io/nats-concepts/jetstream/consumers#push-specific
+type PushConsumerSpec struct {
+	// RateLimit is used to throttle the delivery of messages to the consumer, in bits per second.
+	// +kubebuilder:validation:Optional
+	RateLimit uint64 `json:"rateLimitBps,omitempty"`
+
+	// HeadersOnly delivers, if set, only the headers of messages in the stream and not the bodies.
+	// +kubebuilder:validation:Optional
+	HeadersOnly bool `json:"headersOnly,omitempty"`
+
+	// DeliverSubject defines the subject to deliver messages to.
+	// +kubebuilder:validation:Required
+	DeliverSubject string `json:"deliverSubject,omitempty"`
+
+	// DeliverGroup defines the queue group name which, if specified, is then used to distribute the messages between the subscribers to the consumer.
+	// +kubebuilder:validation:Optional
+	DeliverGroup string `json:"deliverGroup,omitempty"`
+
+	// FlowControl enables per-subscription flow control using a sliding-window protocol.
+	// +kubebuilder:validation:Optional
+	FlowControl bool `json:"flowControl,omitempty"`
+
+	// IdleHeartbeat defines, if set, the period after which the server sends a status message to the client while there are no new messages to send, e.g. 5s.
+	// +kubebuilder:validation:Optional
+	IdleHeartbeat *metav1.Duration `json:"idleHeartbeat,omitempty"`
+}
+
+// PullConsumerSpec defines the pull-based consumer configuration.
+// For more information, see https://docs.nats.io/nats-concepts/jetstream/consumers#pull-specific
+type PullConsumerSpec struct {
+	// MaxWaiting defines the maximum number of waiting pull requests.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=512
+	MaxWaiting *int `json:"maxWaiting,omitempty"`
+
+	// MaxRequestExpires defines the maximum duration a single pull request will wait for messages to be available to pull, e.g. 30s.
+	// +kubebuilder:validation:Optional
+	MaxRequestExpires *metav1.Duration `json:"maxExpires,omitempty"`
+
+	// MaxRequestBatch defines the maximum batch size a single pull request can make.
+	// +kubebuilder:validation:Optional
+	MaxRequestBatch int `json:"maxBatch,omitempty"`
+
+	// MaxRequestMaxBytes defines the maximum total bytes that can be requested in a given batch, e.g. 1048576, 1Mi or 1MB.
+	// +kubebuilder:validation:Optional
+	MaxRequestMaxBytes apisv1alpha1.ByteQuantity `json:"maxBytes,omitempty"`
+}
+
+// ConsumerObservationState is the state of a JetStream consumer.
+type ConsumerObservationState struct {
+	// Domain is the domain of the consumer.
+	Domain string `json:"domain"`
+	// Stream is the stream name.
+	Stream string `json:"streamName"`
+	// Name is the consumer name.
+	Name string `json:"name"`
+	// Durable is the durable name.
+	Durable string `json:"durableName"`
+	// Created is the time the consumer was created.
+	Created *metav1.Time `json:"created,omitempty"`
+	// Delivered is the consumer sequence and last activity.
+	Delivered SequenceInfo `json:"delivered"`
+	// AckFloor is the highest contiguous acknowledged sequence.
+	AckFloor SequenceInfo `json:"ackFloor"`
+	// NumAckPending is the number of messages pending acknowledgement.
+	NumAckPending int `json:"numAckPending"`
+	// NumRedelivered is the number of redelivered messages.
+	NumRedelivered int `json:"numRedelivered"`
+	// NumWaiting is the number of messages waiting to be delivered.
+	NumWaiting int `json:"numWaiting"`
+	// NumPending is the number of messages pending.
+	NumPending uint64 `json:"numPending"`
+	// Cluster is the cluster information.
+	Cluster *ClusterInfo `json:"cluster,omitempty"`
+	// PushBound is whether the consumer is push bound.
+	PushBound bool `json:"pushBound,omitempty"`
+}
+
+// SequenceInfo has both the consumer and the stream sequence and last activity.
+type SequenceInfo struct {
+	// Consumer is the consumer sequence.
+	Consumer uint64 `json:"consumerSeq"`
+	// Stream is the stream sequence.
+	Stream uint64 `json:"streamSeq"`
+	// Last is the last time the consumer was active.
+	Last *metav1.Time `json:"lastActive,omitempty"`
+}
+
+// ClusterInfo shows information about the underlying set of servers that make
+// up the consumer.
+type ClusterInfo struct {
+	// Name is the name of the cluster.
+	Name string `json:"name,omitempty"`
+	// Leader is the leader of the cluster.
+	Leader string `json:"leader,omitempty"`
+	// Replicas are the replicas of the cluster.
+	Replicas []PeerInfo `json:"replicas,omitempty"`
+}
+
+// PeerInfo shows information about a peer in the cluster that is supporting
+// the consumer.
+type PeerInfo struct {
+	// Name is the name of the peer.
+	Name string `json:"name"`
+	// Current is true if the peer is up to date.
+	Current bool `json:"current"`
+	// Offline is true if the peer is offline.
+	Offline bool `json:"offline,omitempty"`
+	// Active is the time since the peer was last active.
+	Active metav1.Duration `json:"active"`
+	// Lag is the number of operations the peer is behind.
+	Lag uint64 `json:"lag,omitempty"`
+}
@@ -0,0 +1,240 @@
+package consumer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1/consumer"
+	convert "github.com/edgefarm/provider-nats/internal/convert"
+)
+
+// statusTimeLayout is the layout of times in the v1alpha1 consumer status.
+const statusTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// ConfigToV1Alpha1 converts a v1beta1 consumer configuration to v1alpha1.
+func ConfigToV1Alpha1(in *ConsumerConfig) (*consumer.ConsumerConfig, error) {
+	optStartTime, err := convert.MetaTimeToRFC3339(in.OptStartTime)
+	if err != nil {
+		return nil, fmt.Errorf("optStartTime: %w", err)
+	}
+	out := &consumer.ConsumerConfig{
+		Description:       in.Description,
+		DeliverPolicy:     in.DeliverPolicy,
+		OptStartSeq:       in.OptStartSeq,
+		OptStartTime:      optStartTime,
+		AckPolicy:         in.AckPolicy,
+		AckWait:           convert.DurationToString(in.AckWait),
+		MaxDeliver:        in.MaxDeliver,
+		BackOff:           convert.DurationsToString(in.BackOff),
+		FilterSubject:     in.FilterSubject,
+		ReplayPolicy:      in.ReplayPolicy,
+		SampleFrequency:   in.SampleFrequency,
+		MaxAckPending:     in.MaxAckPending,
+		InactiveThreshold: convert.DurationToString(in.InactiveThreshold),
+		Replicas:          in.Replicas,
+		MemoryStorage:     in.MemoryStorage,
+	}
+	if p := in.PullConsumer; p != nil {
+		out.PullConsumer = &consumer.PullConsumerSpec{
+			MaxWaiting:         p.MaxWaiting,
+			MaxRequestExpires:  convert.DurationToString(p.MaxRequestExpires),
+			MaxRequestBatch:    p.MaxRequestBatch,
+			MaxRequestMaxBytes: p.MaxRequestMaxBytes,
+		}
+	}
+	if p := in.PushConsumer; p != nil {
+		out.PushConsumer = &consumer.PushConsumerSpec{
+			RateLimit:      p.RateLimit,
+			HeadersOnly:    p.HeadersOnly,
+			DeliverSubject: p.DeliverSubject,
+			DeliverGroup:   p.DeliverGroup,
+			FlowControl:    p.FlowControl,
+			IdleHeartbeat:  convert.DurationToString(p.IdleHeartbeat),
+		}
+	}
+	return out, nil
+}
+
+// ConfigFromV1Alpha1 converts a v1alpha1 consumer configuration to v1beta1.
+func ConfigFromV1Alpha1(in *consumer.ConsumerConfig) (*ConsumerConfig, error) {
+	optStartTime, err := convert.RFC3339ToMetaTime(in.OptStartTime)
+	if err != nil {
+		return nil, fmt.Errorf("optStartTime: %w", err)
+	}
+	ackWait, err := convert.StringToDuration(in.AckWait)
+	if err != nil {
+		return nil, fmt.Errorf("ackWait: %w", err)
+	}
+	backOff, err := convert.StringToDurations(in.BackOff)
+	if err != nil {
+		return nil, fmt.Errorf("backoff: %w", err)
+	}
+	inactiveThreshold, err := convert.StringToDuration(in.InactiveThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("inactiveThreshold: %w", err)
+	}
+	out := &ConsumerConfig{
+		Description:       in.Description,
+		DeliverPolicy:     in.DeliverPolicy,
+		OptStartSeq:       in.OptStartSeq,
+		OptStartTime:      optStartTime,
+		AckPolicy:         in.AckPolicy,
+		AckWait:           ackWait,
+		MaxDeliver:        in.MaxDeliver,
+		BackOff:           backOff,
+		FilterSubject:     in.FilterSubject,
+		ReplayPolicy:      in.ReplayPolicy,
+		SampleFrequency:   in.SampleFrequency,
+		MaxAckPending:     in.MaxAckPending,
+		InactiveThreshold: inactiveThreshold,
+		Replicas:          in.Replicas,
+		MemoryStorage:     in.MemoryStorage,
+	}
+	if p := in.PullConsumer; p != nil {
+		maxRequestExpires, err := convert.StringToDuration(p.MaxRequestExpires)
+		if err != nil {
+			return nil, fmt.Errorf("pull.maxExpires: %w", err)
+		}
+		out.PullConsumer = &PullConsumerSpec{
+			MaxWaiting:         p.MaxWaiting,
+			MaxRequestExpires:  maxRequestExpires,
+			MaxRequestBatch:    p.MaxRequestBatch,
+			MaxRequestMaxBytes: p.MaxRequestMaxBytes,
+		}
+	}
+	if p := in.PushConsumer; p != nil {
+		idleHeartbeat, err := convert.StringToDuration(p.IdleHeartbeat)
+		if err != nil {
+			return nil, fmt.Errorf("push.idleHeartbeat: %w", err)
+		}
+		out.PushConsumer = &PushConsumerSpec{
+			RateLimit:      p.RateLimit,
+			HeadersOnly:    p.HeadersOnly,
+			DeliverSubject: p.DeliverSubject,
+			DeliverGroup:   p.DeliverGroup,
+			FlowControl:    p.FlowControl,
+			IdleHeartbeat:  idleHeartbeat,
+		}
+	}
+	return out, nil
+}
+
+// StateToV1Alpha1 converts a v1beta1 consumer state to v1alpha1.
+func StateToV1Alpha1(in *ConsumerObservationState) *consumer.ConsumerObservationState {
+	out := &consumer.ConsumerObservationState{
+		Domain:         in.Domain,
+		Stream:         in.Stream,
+		Name:           in.Name,
+		Durable:        in.Durable,
+		Created:        statusTimeToV1Alpha1(in.Created),
+		Delivered:      sequenceInfoToV1Alpha1(in.Delivered),
+		AckFloor:       sequenceInfoToV1Alpha1(in.AckFloor),
+		NumAckPending:  in.NumAckPending,
+		NumRedelivered: in.NumRedelivered,
+		NumWaiting:     in.NumWaiting,
+		NumPending:     in.NumPending,
+		PushBound:      "no",
+	}
+	if in.PushBound {
+		out.PushBound = "yes"
+	}
+	if in.Cluster != nil {
+		out.Cluster = &consumer.ClusterInfo{
+			Name:   in.Cluster.Name,
+			Leader: in.Cluster.Leader,
+		}
+		for _, peer := range in.Cluster.Replicas {
+			out.Cluster.Replicas = append(out.Cluster.Replicas, &consumer.PeerInfo{
+				Name:    peer.Name,
+				Current: peer.Current,
+				Offline: peer.Offline,
+				Active:  peer.Active.Duration.String(),
+				Lag:     peer.Lag,
+			})
+		}
+	}
+	return out
+}
+
+// StateFromV1Alpha1 converts a v1alpha1 consumer state to v1beta1. Times and
+// durations that cannot be parsed are dropped, they are set again when the
+// consumer is observed the next time.
+func StateFromV1Alpha1(in *consumer.ConsumerObservationState) *ConsumerObservationState {
+	out := &ConsumerObservationState{
+		Domain:         in.Domain,
+		Stream:         in.Stream,
+		Name:           in.Name,
+		Durable:        in.Durable,
+		Created:        statusTimeFromV1Alpha1(in.Created),
+		Delivered:      sequenceInfoFromV1Alpha1(in.Delivered),
+		AckFloor:       sequenceInfoFromV1Alpha1(in.AckFloor),
+		NumAckPending:  in.NumAckPending,
+		NumRedelivered: in.NumRedelivered,
+		NumWaiting:     in.NumWaiting,
+		NumPending:     in.NumPending,
+		PushBound:      in.PushBound == "yes",
+	}
+	if in.Cluster != nil {
+		out.Cluster = &ClusterInfo{
+			Name:   in.Cluster.Name,
+			Leader: in.Cluster.Leader,
+		}
+		for _, peer := range in.Cluster.Replicas {
+			if peer == nil {
+				continue
+			}
+			active, _ := time.ParseDuration(peer.Active)
+			out.Cluster.Replicas = append(out.Cluster.Replicas, PeerInfo{
+				Name:    peer.Name,
+				Current: peer.Current,
+				Offline: peer.Offline,
+				Active:  metav1.Duration{Duration: active},
+				Lag:     peer.Lag,
+			})
+		}
+	}
+	return out
+}
+
+func sequenceInfoToV1Alpha1(in SequenceInfo) consumer.SequenceInfo {
+	return consumer.SequenceInfo{
+		Consumer: in.Consumer,
+		Stream:   in.Stream,
+		Last:     statusTimeToV1Alpha1(in.Last),
+	}
+}
+
+func sequenceInfoFromV1Alpha1(in consumer.SequenceInfo) SequenceInfo {
+	return SequenceInfo{
+		Consumer: in.Consumer,
+		Stream:   in.Stream,
+		Last:     statusTimeFromV1Alpha1(in.Last),
+	}
+}
+
+func statusTimeToV1Alpha1(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Time.String()
+}
+
+func statusTimeFromV1Alpha1(s string) *metav1.Time {
+	if s == "" {
+		return nil
+	}
+	// Drop the monotonic clock reading time.Time.String() may append.
+	if i := strings.Index(s, " m="); i >= 0 {
+		s = s[:i]
+	}
+	if t, err := time.Parse(statusTimeLayout, s); err == nil {
+		return &metav1.Time{Time: t}
+	}
+	if t, err := convert.RFC3339ToMetaTime(s); err == nil {
+		return t
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1/consumer"
+)
+
+func TestConfigConversion(t *testing.T) {
+	assert := assert.New(t)
+	optStartTime := metav1.NewTime(time.Date(2023, 1, 9, 14, 48, 32, 0, time.UTC))
+	maxWaiting := 10
+
+	in := &ConsumerConfig{
+		DeliverPolicy:     "ByStartTime",
+		OptStartTime:      &optStartTime,
+		AckPolicy:         "Explicit",
+		AckWait:           &metav1.Duration{Duration: 30 * time.Second},
+		MaxDeliver:        -1,
+		BackOff:           []metav1.Duration{{Duration: time.Second}, {Duration: time.Minute}},
+		ReplayPolicy:      "Instant",
+		InactiveThreshold: &metav1.Duration{Duration: time.Hour},
+		PullConsumer: &PullConsumerSpec{
+			MaxWaiting:         &maxWaiting,
+			MaxRequestExpires:  &metav1.Duration{Duration: 5 * time.Second},
+			MaxRequestMaxBytes: "1Mi",
+		},
+	}
+
+	alpha, err := ConfigToV1Alpha1(in)
+	assert.Nil(err)
+	assert.Equal("2023-01-09T14:48:32Z", alpha.OptStartTime)
+	assert.Equal("30s", alpha.AckWait)
+	assert.Equal("1s,1m0s", alpha.BackOff)
+	assert.Equal("1h0m0s", alpha.InactiveThreshold)
+	assert.Equal("5s", alpha.PullConsumer.MaxRequestExpires)
+	assert.Nil(alpha.PushConsumer)
+
+	out, err := ConfigFromV1Alpha1(alpha)
+	assert.Nil(err)
+	assert.True(out.OptStartTime.Equal(&optStartTime))
+	out.OptStartTime = in.OptStartTime
+	assert.Equal(in, out)
+}
+
+func TestConfigFromV1Alpha1(t *testing.T) {
+	assert := assert.New(t)
+
+	out, err := ConfigFromV1Alpha1(&consumer.ConsumerConfig{
+		AckWait: "30s",
+		BackOff: "1s, 2s,5s",
+		PushConsumer: &consumer.PushConsumerSpec{
+			DeliverSubject: "deliver",
+			IdleHeartbeat:  "5s",
+		},
+	})
+	assert.Nil(err)
+	assert.Equal([]metav1.Duration{{Duration: time.Second}, {Duration: 2 * time.Second}, {Duration: 5 * time.Second}}, out.BackOff)
+	assert.Equal(5*time.Second, out.PushConsumer.IdleHeartbeat.Duration)
+	assert.Nil(out.InactiveThreshold)
+
+	_, err = ConfigFromV1Alpha1(&consumer.ConsumerConfig{BackOff: "1s,soon"})
+	assert.ErrorContains(err, "backoff")
+}
+
+func TestStateConversion(t *testing.T) {
+	assert := assert.New(t)
+	created := metav1.NewTime(time.Date(2023, 1, 9, 14, 48, 32, 0, time.UTC))
+
+	in := &ConsumerObservationState{
+		Domain:    "leaf",
+		Stream:    "orders",
+		Name:      "worker",
+		Durable:   "worker",
+		Created:   &created,
+		Delivered: SequenceInfo{Consumer: 3, Stream: 5, Last: &created},
+		AckFloor:  SequenceInfo{Consumer: 2, Stream: 4},
+		PushBound: true,
+		Cluster: &ClusterInfo{
+			Name:     "c1",
+			Replicas: []PeerInfo{{Name: "n2", Active: metav1.Duration{Duration: time.Second}}},
+		},
+	}
+	alpha := StateToV1Alpha1(in)
+	assert.Equal("yes", alpha.PushBound)
+	assert.Equal("1s", alpha.Cluster.Replicas[0].Active)
+
+	out := StateFromV1Alpha1(alpha)
+	assert.True(out.Created.Equal(&created))
+	assert.True(out.Delivered.Last.Equal(&created))
+	out.Created = in.Created
+	out.Delivered.Last = in.Delivered.Last
+	assert.Equal(in, out)
+
+	// Times as written by the controller and unparsable times.
+	out = StateFromV1Alpha1(&consumer.ConsumerObservationState{
+		Created:   time.Date(2023, 1, 9, 14, 48, 32, 0, time.UTC).String(),
+		Delivered: consumer.SequenceInfo{Last: "sometime"},
+	})
+	assert.True(out.Created.Equal(&created))
+	assert.Nil(out.Delivered.Last)
+	assert.False(out.PushBound)
+}
@@ -0,0 +1,196 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package consumer
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInfo) DeepCopyInto(out *ClusterInfo) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]PeerInfo, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInfo.
+func (in *ClusterInfo) DeepCopy() *ClusterInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerConfig) DeepCopyInto(out *ConsumerConfig) {
+	*out = *in
+	if in.OptStartTime != nil {
+		in, out := &in.OptStartTime, &out.OptStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AckWait != nil {
+		in, out := &in.AckWait, &out.AckWait
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BackOff != nil {
+		in, out := &in.BackOff, &out.BackOff
+		*out = make([]v1.Duration, len(*in))
+		copy(*out, *in)
+	}
+	if in.InactiveThreshold != nil {
+		in, out := &in.InactiveThreshold, &out.InactiveThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PullConsumer != nil {
+		in, out := &in.PullConsumer, &out.PullConsumer
+		*out = new(PullConsumerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PushConsumer != nil {
+		in, out := &in.PushConsumer, &out.PushConsumer
+		*out = new(PushConsumerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerConfig.
+func (in *ConsumerConfig) DeepCopy() *ConsumerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerObservationState) DeepCopyInto(out *ConsumerObservationState) {
+	*out = *in
+	if in.Created != nil {
+		in, out := &in.Created, &out.Created
+		*out = (*in).DeepCopy()
+	}
+	in.Delivered.DeepCopyInto(&out.Delivered)
+	in.AckFloor.DeepCopyInto(&out.AckFloor)
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ClusterInfo)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerObservationState.
+func (in *ConsumerObservationState) DeepCopy() *ConsumerObservationState {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerObservationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerInfo) DeepCopyInto(out *PeerInfo) {
+	*out = *in
+	out.Active = in.Active
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerInfo.
+func (in *PeerInfo) DeepCopy() *PeerInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullConsumerSpec) DeepCopyInto(out *PullConsumerSpec) {
+	*out = *in
+	if in.MaxWaiting != nil {
+		in, out := &in.MaxWaiting, &out.MaxWaiting
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxRequestExpires != nil {
+		in, out := &in.MaxRequestExpires, &out.MaxRequestExpires
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullConsumerSpec.
+func (in *PullConsumerSpec) DeepCopy() *PullConsumerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PullConsumerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushConsumerSpec) DeepCopyInto(out *PushConsumerSpec) {
+	*out = *in
+	if in.IdleHeartbeat != nil {
+		in, out := &in.IdleHeartbeat, &out.IdleHeartbeat
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushConsumerSpec.
+func (in *PushConsumerSpec) DeepCopy() *PushConsumerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PushConsumerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SequenceInfo) DeepCopyInto(out *SequenceInfo) {
+	*out = *in
+	if in.Last != nil {
+		in, out := &in.Last, &out.Last
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SequenceInfo.
+func (in *SequenceInfo) DeepCopy() *SequenceInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(SequenceInfo)
+	in.DeepCopyInto(out)
+	return out
+}
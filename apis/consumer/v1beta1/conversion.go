@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1"
+	"github.com/edgefarm/provider-nats/apis/consumer/v1beta1/consumer"
+)
+
+var _ conversion.Convertible = &Consumer{}
+
+// ConvertTo converts this Consumer to the hub version v1alpha1.
+func (mg *Consumer) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1alpha1.Consumer)
+	config, err := consumer.ConfigToV1Alpha1(&mg.Spec.ForProvider.Config)
+	if err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = mg.ObjectMeta
+	dst.Spec = v1alpha1.ConsumerSpec{
+		ResourceSpec:     mg.Spec.ResourceSpec,
+		ManagementPolicy: mg.Spec.ManagementPolicy,
+		ForProvider: v1alpha1.ConsumerParameters{
+			Stream:         mg.Spec.ForProvider.Stream,
+			StreamRef:      mg.Spec.ForProvider.StreamRef,
+			StreamSelector: mg.Spec.ForProvider.StreamSelector,
+			Domain:         mg.Spec.ForProvider.Domain,
+			APIPrefix:      mg.Spec.ForProvider.APIPrefix,
+			UpdatePolicy:   mg.Spec.ForProvider.UpdatePolicy,
+			Health:         mg.Spec.ForProvider.Health,
+			Config:         *config,
+		},
+	}
+	dst.Status = v1alpha1.ConsumerStatus{
+		ResourceStatus: mg.Status.ResourceStatus,
+		AtProvider: v1alpha1.ConsumerObservation{
+			State: *consumer.StateToV1Alpha1(&mg.Status.AtProvider.State),
+		},
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version v1alpha1 to this Consumer.
+func (mg *Consumer) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1alpha1.Consumer)
+	config, err := consumer.ConfigFromV1Alpha1(&src.Spec.ForProvider.Config)
+	if err != nil {
+		return err
+	}
+
+	mg.ObjectMeta = src.ObjectMeta
+	mg.Spec = ConsumerSpec{
+		ResourceSpec:     src.Spec.ResourceSpec,
+		ManagementPolicy: src.Spec.ManagementPolicy,
+		ForProvider: ConsumerParameters{
+			Stream:         src.Spec.ForProvider.Stream,
+			StreamRef:      src.Spec.ForProvider.StreamRef,
+			StreamSelector: src.Spec.ForProvider.StreamSelector,
+			Domain:         src.Spec.ForProvider.Domain,
+			APIPrefix:      src.Spec.ForProvider.APIPrefix,
+			UpdatePolicy:   src.Spec.ForProvider.UpdatePolicy,
+			Health:         src.Spec.ForProvider.Health,
+			Config:         *config,
+		},
+	}
+	mg.Status = ConsumerStatus{
+		ResourceStatus: src.Status.ResourceStatus,
+		AtProvider: ConsumerObservation{
+			State: *consumer.StateFromV1Alpha1(&src.Status.AtProvider.State),
+		},
+	}
+	return nil
+}
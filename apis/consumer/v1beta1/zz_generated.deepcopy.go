@@ -0,0 +1,168 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/edgefarm/provider-nats/apis/v1alpha1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Consumer) DeepCopyInto(out *Consumer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Consumer.
+func (in *Consumer) DeepCopy() *Consumer {
+	if in == nil {
+		return nil
+	}
+	out := new(Consumer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Consumer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerList) DeepCopyInto(out *ConsumerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Consumer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerList.
+func (in *ConsumerList) DeepCopy() *ConsumerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsumerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerObservation) DeepCopyInto(out *ConsumerObservation) {
+	*out = *in
+	in.State.DeepCopyInto(&out.State)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerObservation.
+func (in *ConsumerObservation) DeepCopy() *ConsumerObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerParameters) DeepCopyInto(out *ConsumerParameters) {
+	*out = *in
+	if in.StreamRef != nil {
+		in, out := &in.StreamRef, &out.StreamRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StreamSelector != nil {
+		in, out := &in.StreamSelector, &out.StreamSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(v1alpha1.ConsumerHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Config.DeepCopyInto(&out.Config)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerParameters.
+func (in *ConsumerParameters) DeepCopy() *ConsumerParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerSpec) DeepCopyInto(out *ConsumerSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerSpec.
+func (in *ConsumerSpec) DeepCopy() *ConsumerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerStatus) DeepCopyInto(out *ConsumerStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerStatus.
+func (in *ConsumerStatus) DeepCopy() *ConsumerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -26,12 +26,19 @@ limitations under the License.
 // Generate deepcopy methodsets and CRD manifests
 //go:generate go run -tags generate sigs.k8s.io/controller-tools/cmd/controller-gen object:headerFile=../hack/boilerplate.go.txt paths=./... crd:crdVersions=v1 output:artifacts:config=../package/crds
 
+// Enable the conversion webhook of resources with multiple versions
+//go:generate go run -tags generate ../hack/crdconversion ../package/crds/nats.crossplane.io_streams.yaml ../package/crds/nats.crossplane.io_consumers.yaml
+
+// Generate webhook configurations
+//go:generate rm -rf ../package/webhookconfigurations
+//go:generate go run -tags generate sigs.k8s.io/controller-tools/cmd/controller-gen webhook paths=./... output:webhook:artifacts:config=../package/webhookconfigurations
+
 // Generate crossplane-runtime methodsets (resource.Claim, etc)
 //go:generate go run -tags generate github.com/crossplane/crossplane-tools/cmd/angryjet generate-methodsets --header-file=../hack/boilerplate.go.txt ./...
 
 // Generate clientset for types.
 //go:generate rm -rf ../internal/clientset
-//go:generate go run -tags generate k8s.io/code-generator/cmd/client-gen --clientset-name "provider" --build-tag="ignore_autogenerated" --go-header-file "../hack/boilerplate.go.txt" --output-package "github.com/edgefarm/provider-nats/internal/clientset" --input-base "github.com/edgefarm/provider-nats/apis" --output-base "../tmp-clientgen" --input "stream/v1alpha1,consumer/v1alpha1"
+//go:generate go run -tags generate k8s.io/code-generator/cmd/client-gen --clientset-name "provider" --build-tag="ignore_autogenerated" --go-header-file "../hack/boilerplate.go.txt" --output-package "github.com/edgefarm/provider-nats/internal/clientset" --input-base "github.com/edgefarm/provider-nats/apis" --output-base "../tmp-clientgen" --input "stream/v1alpha1,consumer/v1alpha1,keyvalue/v1alpha1,objectstore/v1alpha1,backup/v1alpha1"
 //go:generate cp -r ../tmp-clientgen/github.com/edgefarm/provider-nats/internal/clientset ../internal/clientset
 //go:generate rm -rf ../tmp-clientgen
 
@@ -0,0 +1,113 @@
+package v1alpha1
+
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/edgefarm/provider-nats/apis/keyvalue/v1alpha1/keyvalue"
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// KeyValueParameters are the configurable fields of a KeyValue bucket.
+type KeyValueParameters struct {
+	// Domain is the Jetstream domain in which the bucket is created.
+	// +kubebuilder:validation:Optional
+	Domain string `json:"domain,omitempty"`
+
+	// Config is the bucket configuration.
+	Config keyvalue.KeyValueConfig `json:"config"`
+}
+
+// KeyValueObservation are the observable fields of a KeyValue bucket.
+type KeyValueObservation struct {
+	// Domain is the Jetstream domain in which the bucket is created.
+	Domain string `json:"domain,omitempty"`
+
+	// State is the current state of the bucket
+	State keyvalue.KeyValueObservationState `json:"state,omitempty"`
+
+	// ClusterInfo shows information about the underlying set of servers that make up the bucket.
+	ClusterInfo stream.StreamObservationClusterInfo `json:"clusterInfo,omitempty"`
+
+	// Connection shows information about the connection to the bucket.
+	Connection stream.StreamObservationConnection `json:"connection,omitempty"`
+}
+
+// A KeyValueSpec defines the desired state of a KeyValue bucket.
+type KeyValueSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       KeyValueParameters `json:"forProvider"`
+}
+
+// A KeyValueStatus represents the observed state of a KeyValue bucket.
+type KeyValueStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          KeyValueObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+// +genclient
+// +genclient:nonNamespaced
+
+// A KeyValue is a managed resource that represents a JetStream key/value bucket.
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DOMAIN",type="string",JSONPath=".spec.forProvider.domain"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="ADDRESS",type="string",priority=1,JSONPath=".status.atProvider.connection.address"
+// +kubebuilder:printcolumn:name="ACCOUNT PUB KEY",type="string",priority=1,JSONPath=".status.atProvider.connection.accountPublicKey"
+// +kubebuilder:printcolumn:name="VALUES",type="string",priority=1,JSONPath=".status.atProvider.state.values"
+// +kubebuilder:printcolumn:name="BYTES",type="string",priority=1,JSONPath=".status.atProvider.state.bytes"
+// +kubebuilder:printcolumn:name="HISTORY",type="string",priority=1,JSONPath=".status.atProvider.state.history"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,nats}
+type KeyValue struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeyValueSpec   `json:"spec"`
+	Status KeyValueStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KeyValueList contains a list of KeyValue
+type KeyValueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KeyValue `json:"items"`
+}
+
+// KeyValue type metadata.
+var (
+	KeyValueKind             = reflect.TypeOf(KeyValue{}).Name()
+	KeyValueGroupKind        = schema.GroupKind{Group: Group, Kind: KeyValueKind}.String()
+	KeyValueKindAPIVersion   = KeyValueKind + "." + SchemeGroupVersion.String()
+	KeyValueGroupVersionKind = SchemeGroupVersion.WithKind(KeyValueKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&KeyValue{}, &KeyValueList{})
+}
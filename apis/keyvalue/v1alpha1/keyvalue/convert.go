@@ -0,0 +1,116 @@
+package keyvalue
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+func convertStorage(storage string) nats.StorageType {
+	switch storage {
+	case "File":
+		return nats.FileStorage
+	case "Memory":
+		return nats.MemoryStorage
+	default:
+		return nats.FileStorage
+	}
+}
+
+func convertBase(bucket string, config *KeyValueConfig) *nats.KeyValueConfig {
+	return &nats.KeyValueConfig{
+		Bucket:       bucket,
+		Description:  config.Description,
+		MaxValueSize: config.MaxValueSize,
+		History:      uint8(config.History),
+		MaxBytes:     config.MaxBytes,
+		Storage:      convertStorage(config.Storage),
+		Replicas:     config.Replicas,
+	}
+}
+
+func convertDurations(in *KeyValueConfig, out *nats.KeyValueConfig) error {
+	if in.TTL != "" {
+		ttl, err := time.ParseDuration(in.TTL)
+		if err != nil {
+			return err
+		}
+		out.TTL = ttl
+	}
+	return nil
+}
+
+// convertSource converts a bucket source the same way as a stream source.
+func convertSource(in *KeyValueSource) (*nats.StreamSource, error) {
+	return stream.ConvertStreamSource(&stream.StreamSource{
+		Name:          in.Name,
+		StartSeq:      in.StartSeq,
+		StartTime:     in.StartTime,
+		FilterSubject: in.FilterSubject,
+		Domain:        in.Domain,
+		External:      in.External,
+	})
+}
+
+func convertMirror(in *KeyValueConfig, out *nats.KeyValueConfig) error {
+	if in.Mirror != nil {
+		mirrorConfig, err := convertSource(in.Mirror)
+		if err != nil {
+			return err
+		}
+		out.Mirror = mirrorConfig
+	}
+	return nil
+}
+
+func convertSources(in *KeyValueConfig, out *nats.KeyValueConfig) error {
+	if in.Sources != nil {
+		sources := []*nats.StreamSource{}
+		for _, source := range in.Sources {
+			streamSource, err := convertSource(source)
+			if err != nil {
+				return err
+			}
+			sources = append(sources, streamSource)
+		}
+		out.Sources = sources
+	}
+	return nil
+}
+
+func ConfigV1Alpha1ToNats(bucket string, config *KeyValueConfig) (*nats.KeyValueConfig, error) {
+	natsConfig := convertBase(bucket, config)
+	err := convertDurations(config, natsConfig)
+	if err != nil {
+		return &nats.KeyValueConfig{}, err
+	}
+
+	if config.Placement != nil {
+		natsConfig.Placement = &nats.Placement{
+			Cluster: config.Placement.Cluster,
+			Tags:    config.Placement.Tags,
+		}
+	}
+
+	if config.RePublish != nil {
+		natsConfig.RePublish = &nats.RePublish{
+			Source:      config.RePublish.Source,
+			Destination: config.RePublish.Destination,
+			HeadersOnly: config.RePublish.HeadersOnly,
+		}
+	}
+
+	err = convertMirror(config, natsConfig)
+	if err != nil {
+		return &nats.KeyValueConfig{}, err
+	}
+
+	err = convertSources(config, natsConfig)
+	if err != nil {
+		return &nats.KeyValueConfig{}, err
+	}
+
+	return natsConfig, nil
+}
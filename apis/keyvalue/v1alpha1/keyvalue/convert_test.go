@@ -0,0 +1,78 @@
+package keyvalue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+func TestConvertToNats(t *testing.T) {
+	assert := assert.New(t)
+	ttl := "1h30m"
+
+	customConfig := &KeyValueConfig{
+		Description:  "this is a test bucket",
+		History:      5,
+		TTL:          ttl,
+		MaxValueSize: 1024,
+		MaxBytes:     -1,
+		Storage:      "Memory",
+		Replicas:     3,
+		Placement: &stream.Placement{
+			Cluster: "mycluster",
+		},
+		RePublish: &stream.RePublish{
+			Source:      ">",
+			Destination: "republish.>",
+		},
+		Sources: []*KeyValueSource{
+			{
+				Name:   "origin",
+				Domain: "mydomain",
+			},
+		},
+	}
+
+	natsConfig, err := ConfigV1Alpha1ToNats("mybucket", customConfig)
+	assert.Nil(err)
+	assert.Equal(natsConfig.Bucket, "mybucket")
+	assert.Equal(natsConfig.Description, "this is a test bucket")
+	assert.Equal(natsConfig.History, uint8(5))
+	assert.Equal(natsConfig.TTL, func() time.Duration {
+		t, _ := time.ParseDuration(ttl)
+		return t
+	}())
+	assert.Equal(natsConfig.MaxValueSize, int32(1024))
+	assert.Equal(natsConfig.MaxBytes, int64(-1))
+	assert.Equal(natsConfig.Storage, nats.MemoryStorage)
+	assert.Equal(natsConfig.Replicas, 3)
+	assert.Equal(natsConfig.Placement, &nats.Placement{
+		Cluster: "mycluster",
+	})
+	assert.Equal(natsConfig.RePublish, &nats.RePublish{
+		Source:      ">",
+		Destination: "republish.>",
+	})
+	assert.Nil(natsConfig.Mirror)
+	assert.Equal(natsConfig.Sources, []*nats.StreamSource{
+		{
+			Name:   "origin",
+			Domain: "mydomain",
+		},
+	})
+}
+
+func TestConvertToNatsInvalidTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	customConfig := &KeyValueConfig{
+		TTL: "foo",
+	}
+
+	_, err := ConfigV1Alpha1ToNats("mybucket", customConfig)
+	assert.NotNil(err)
+}
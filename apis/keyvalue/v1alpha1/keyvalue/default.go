@@ -0,0 +1,10 @@
+package keyvalue
+
+func (k *KeyValueConfig) SetDefaults() {
+	k.History = 1
+	k.TTL = "0s"
+	k.MaxValueSize = -1
+	k.MaxBytes = -1
+	k.Storage = "File"
+	k.Replicas = 1
+}
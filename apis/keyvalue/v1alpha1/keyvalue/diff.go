@@ -0,0 +1,65 @@
+package keyvalue
+
+import (
+	"strings"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// streamPrefix is the prefix of the name of the stream backing a bucket.
+const streamPrefix = "KV_"
+
+// DiffNatsConfig returns the names of the fields of the desired bucket
+// configuration that differ from the observed one. Defaults the server and
+// nats.go apply to unset fields are not reported as differences.
+func DiffNatsConfig(desired *nats.KeyValueConfig, observed *nats.KeyValueConfig) []string {
+	diff := []string{}
+	add := func(field string, differs bool) {
+		if differs {
+			diff = append(diff, field)
+		}
+	}
+
+	add("Description", desired.Description != observed.Description)
+	add("History", history(desired.History) != history(observed.History))
+	add("TTL", desired.TTL != observed.TTL)
+	add("MaxValueSize", !stream.EqualLimit(int64(desired.MaxValueSize), int64(observed.MaxValueSize)))
+	add("MaxBytes", !stream.EqualLimit(desired.MaxBytes, observed.MaxBytes))
+	add("Storage", desired.Storage != observed.Storage)
+	add("Replicas", desired.Replicas != 0 && desired.Replicas != observed.Replicas)
+	add("Placement", !stream.EqualPlacement(desired.Placement, observed.Placement))
+	add("RePublish", !stream.EqualRePublish(desired.RePublish, observed.RePublish))
+	add("Mirror", !stream.EqualStreamSource(bucketSource(desired.Mirror), bucketSource(observed.Mirror)))
+	add("Sources", !stream.EqualStreamSources(bucketSources(desired.Sources), bucketSources(observed.Sources)))
+
+	return diff
+}
+
+// history returns the number of values kept per key, which defaults to 1.
+func history(h uint8) uint8 {
+	if h == 0 {
+		return 1
+	}
+	return h
+}
+
+// bucketSource returns a copy of a source that refers to the bucket instead
+// of its backing stream, as sources may be given either way.
+func bucketSource(s *nats.StreamSource) *nats.StreamSource {
+	if s == nil {
+		return nil
+	}
+	out := *s
+	out.Name = strings.TrimPrefix(out.Name, streamPrefix)
+	return &out
+}
+
+func bucketSources(sources []*nats.StreamSource) []*nats.StreamSource {
+	out := make([]*nats.StreamSource, 0, len(sources))
+	for _, s := range sources {
+		out = append(out, bucketSource(s))
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package keyvalue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffNatsConfigServerDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := &nats.KeyValueConfig{
+		Bucket:       "mybucket",
+		History:      0,
+		MaxValueSize: 0,
+		MaxBytes:     0,
+		Replicas:     0,
+		Placement:    &nats.Placement{},
+		Sources:      []*nats.StreamSource{{Name: "KV_other", Domain: "foo"}},
+	}
+	observed := &nats.KeyValueConfig{
+		Bucket:       "mybucket",
+		History:      1,
+		MaxValueSize: -1,
+		MaxBytes:     -1,
+		Replicas:     1,
+		Sources:      []*nats.StreamSource{{Name: "other", External: &nats.ExternalStream{APIPrefix: "$JS.foo.API"}}},
+	}
+
+	assert.Empty(DiffNatsConfig(desired, observed))
+}
+
+func TestDiffNatsConfigChangedFields(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := &nats.KeyValueConfig{
+		Bucket:       "mybucket",
+		Description:  "new",
+		History:      5,
+		TTL:          time.Hour,
+		MaxValueSize: 1024,
+		MaxBytes:     -1,
+		Storage:      nats.MemoryStorage,
+		Replicas:     3,
+		Mirror:       &nats.StreamSource{Name: "other"},
+	}
+	observed := &nats.KeyValueConfig{
+		Bucket:       "mybucket",
+		History:      1,
+		MaxValueSize: -1,
+		MaxBytes:     -1,
+		Storage:      nats.FileStorage,
+		Replicas:     1,
+	}
+
+	assert.Equal([]string{"Description", "History", "TTL", "MaxValueSize", "Storage", "Replicas", "Mirror"}, DiffNatsConfig(desired, observed))
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvalue
+
+import (
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// +kubebuilder:object:generate=true
+// KeyValueConfig will determine the properties for a JetStream key/value bucket.
+// For more information see https://docs.nats.io/nats-concepts/jetstream/key-value-store
+type KeyValueConfig struct {
+	// Description is a human readable description of the bucket.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// History defines how many historical values to keep per key.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	// +kubebuilder:validation:Optional
+	History int `json:"history"`
+
+	// TTL is the maximum age of a value in the bucket.
+	// Format is a string duration, e.g. 1h, 1m, 1s, 1h30m or 2h3m4s.
+	// +kubebuilder:validation:Pattern="([0-9]+h)?([0-9]+m)?([0-9]+s)?"
+	// +kubebuilder:default="0s"
+	// +kubebuilder:validation:Optional
+	TTL string `json:"ttl"`
+
+	// MaxValueSize defines the largest value that will be accepted by the bucket.
+	// +kubebuilder:default=-1
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:validation:Optional
+	MaxValueSize int32 `json:"maxValueSize"`
+
+	// MaxBytes defines how many bytes the bucket may contain.
+	// +kubebuilder:default=-1
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:validation:Optional
+	MaxBytes int64 `json:"maxBytes"`
+
+	// Storage defines the storage type for the bucket data.
+	// +kubebuilder:validation:Enum=File;Memory
+	// +kubebuilder:default=File
+	Storage string `json:"storage"`
+
+	// Replicas defines how many replicas to keep for each value in a clustered JetStream.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +kubebuilder:validation:Optional
+	Replicas int `json:"replicas"`
+
+	// Placement is the placement policy for the bucket.
+	// +kubebuilder:validation:Optional
+	Placement *stream.Placement `json:"placement,omitempty"`
+
+	// RePublish allows republishing of values once they are stored in the bucket.
+	// +kubebuilder:validation:Optional
+	RePublish *stream.RePublish `json:"rePublish,omitempty"`
+
+	// Mirror is the mirror configuration for the bucket.
+	// +kubebuilder:validation:Optional
+	Mirror *KeyValueSource `json:"mirror,omitempty"`
+
+	// Sources is the list of one or more sources configurations for the bucket.
+	// +kubebuilder:validation:Optional
+	Sources []*KeyValueSource `json:"sources,omitempty"`
+}
+
+// KeyValueSource dictates how buckets can source from other buckets.
+type KeyValueSource struct {
+	// Name of the origin bucket to source values from.
+	Name string `json:"name"`
+
+	// StartSeq is an optional start sequence the of the origin bucket to start mirroring from.
+	// +kubebuilder:validation:Optional
+	StartSeq uint64 `json:"startSeq,omitempty"`
+
+	// StartTime is an optional start time to start mirroring from. Any values that are equal to or greater than the start time will be included.
+	// The time format is RFC 3339, e.g. 2023-01-09T14:48:32Z
+	// +kubebuilder:validation:Pattern="^((?:(\\d{4}-\\d{2}-\\d{2})T(\\d{2}:\\d{2}:\\d{2}(?:\\.\\d+)?))(Z|[\\+-]\\d{2}:\\d{2})?)$"
+	// +kubebuilder:validation:Optional
+	StartTime string `json:"startTime,omitempty"`
+
+	// FilterSubject is an optional filter subject which will include only values that match the subject, typically including a wildcard.
+	// +kubebuilder:validation:Optional
+	FilterSubject string `json:"filterSubject,omitempty"`
+
+	// Domain is the JetStream domain of where the origin bucket exists.
+	// +kubebuilder:validation:Optional
+	Domain string `json:"domain,omitempty"`
+
+	// External is the external stream configuration of the origin bucket.
+	// +kubebuilder:validation:Optional
+	External *stream.ExternalStream `json:"external,omitempty"`
+}
+
+// KeyValueObservationState is the current state of a key/value bucket.
+type KeyValueObservationState struct {
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Values is the number of values in the bucket, including historical values.
+	Values uint64 `json:"values"`
+	// Bytes is the size of the bucket.
+	Bytes string `json:"bytes"`
+	// History is the number of historical values kept per key.
+	History int64 `json:"history"`
+	// TTL is how long the bucket keeps values for.
+	TTL string `json:"ttl,omitempty"`
+	// BackingStore indicates what technology is used for storage of the bucket.
+	BackingStore string `json:"backingStore,omitempty"`
+}
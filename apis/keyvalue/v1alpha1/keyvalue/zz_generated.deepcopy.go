@@ -0,0 +1,102 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package keyvalue
+
+import (
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyValueConfig) DeepCopyInto(out *KeyValueConfig) {
+	*out = *in
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(stream.Placement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RePublish != nil {
+		in, out := &in.RePublish, &out.RePublish
+		*out = new(stream.RePublish)
+		**out = **in
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(KeyValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]*KeyValueSource, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(KeyValueSource)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyValueConfig.
+func (in *KeyValueConfig) DeepCopy() *KeyValueConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyValueConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyValueObservationState) DeepCopyInto(out *KeyValueObservationState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyValueObservationState.
+func (in *KeyValueObservationState) DeepCopy() *KeyValueObservationState {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyValueObservationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyValueSource) DeepCopyInto(out *KeyValueSource) {
+	*out = *in
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(stream.ExternalStream)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyValueSource.
+func (in *KeyValueSource) DeepCopy() *KeyValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -21,8 +21,13 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	backupv1alpha1 "github.com/edgefarm/provider-nats/apis/backup/v1alpha1"
 	consumerv1alpha1 "github.com/edgefarm/provider-nats/apis/consumer/v1alpha1"
+	consumerv1beta1 "github.com/edgefarm/provider-nats/apis/consumer/v1beta1"
+	keyvaluev1alpha1 "github.com/edgefarm/provider-nats/apis/keyvalue/v1alpha1"
+	objectstorev1alpha1 "github.com/edgefarm/provider-nats/apis/objectstore/v1alpha1"
 	stream1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+	streamv1beta1 "github.com/edgefarm/provider-nats/apis/stream/v1beta1"
 	natsv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
 )
 
@@ -32,6 +37,11 @@ func init() {
 		natsv1alpha1.SchemeBuilder.AddToScheme,
 		stream1alpha1.SchemeBuilder.AddToScheme,
 		consumerv1alpha1.SchemeBuilder.AddToScheme,
+		streamv1beta1.SchemeBuilder.AddToScheme,
+		consumerv1beta1.SchemeBuilder.AddToScheme,
+		keyvaluev1alpha1.SchemeBuilder.AddToScheme,
+		objectstorev1alpha1.SchemeBuilder.AddToScheme,
+		backupv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -0,0 +1,112 @@
+package v1alpha1
+
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/edgefarm/provider-nats/apis/objectstore/v1alpha1/objectstore"
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// ObjectStoreParameters are the configurable fields of an ObjectStore bucket.
+type ObjectStoreParameters struct {
+	// Domain is the Jetstream domain in which the bucket is created.
+	// +kubebuilder:validation:Optional
+	Domain string `json:"domain,omitempty"`
+
+	// Config is the bucket configuration.
+	Config objectstore.ObjectStoreConfig `json:"config"`
+}
+
+// ObjectStoreObservation are the observable fields of an ObjectStore bucket.
+type ObjectStoreObservation struct {
+	// Domain is the Jetstream domain in which the bucket is created.
+	Domain string `json:"domain,omitempty"`
+
+	// State is the current state of the bucket
+	State objectstore.ObjectStoreObservationState `json:"state,omitempty"`
+
+	// ClusterInfo shows information about the underlying set of servers that make up the bucket.
+	ClusterInfo stream.StreamObservationClusterInfo `json:"clusterInfo,omitempty"`
+
+	// Connection shows information about the connection to the bucket.
+	Connection stream.StreamObservationConnection `json:"connection,omitempty"`
+}
+
+// An ObjectStoreSpec defines the desired state of an ObjectStore bucket.
+type ObjectStoreSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ObjectStoreParameters `json:"forProvider"`
+}
+
+// An ObjectStoreStatus represents the observed state of an ObjectStore bucket.
+type ObjectStoreStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ObjectStoreObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:object:generate=true
+// +genclient
+// +genclient:nonNamespaced
+
+// An ObjectStore is a managed resource that represents a JetStream object store bucket.
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DOMAIN",type="string",JSONPath=".spec.forProvider.domain"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="ADDRESS",type="string",priority=1,JSONPath=".status.atProvider.connection.address"
+// +kubebuilder:printcolumn:name="ACCOUNT PUB KEY",type="string",priority=1,JSONPath=".status.atProvider.connection.accountPublicKey"
+// +kubebuilder:printcolumn:name="SIZE",type="string",priority=1,JSONPath=".status.atProvider.state.size"
+// +kubebuilder:printcolumn:name="TTL",type="string",priority=1,JSONPath=".status.atProvider.state.ttl"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,nats}
+type ObjectStore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ObjectStoreSpec   `json:"spec"`
+	Status ObjectStoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObjectStoreList contains a list of ObjectStore
+type ObjectStoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObjectStore `json:"items"`
+}
+
+// ObjectStore type metadata.
+var (
+	ObjectStoreKind             = reflect.TypeOf(ObjectStore{}).Name()
+	ObjectStoreGroupKind        = schema.GroupKind{Group: Group, Kind: ObjectStoreKind}.String()
+	ObjectStoreKindAPIVersion   = ObjectStoreKind + "." + SchemeGroupVersion.String()
+	ObjectStoreGroupVersionKind = SchemeGroupVersion.WithKind(ObjectStoreKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ObjectStore{}, &ObjectStoreList{})
+}
@@ -0,0 +1,56 @@
+package objectstore
+
+import (
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+func convertStorage(storage string) nats.StorageType {
+	switch storage {
+	case "File":
+		return nats.FileStorage
+	case "Memory":
+		return nats.MemoryStorage
+	default:
+		return nats.FileStorage
+	}
+}
+
+func convertBase(bucket string, config *ObjectStoreConfig) *nats.ObjectStoreConfig {
+	return &nats.ObjectStoreConfig{
+		Bucket:      bucket,
+		Description: config.Description,
+		MaxBytes:    config.MaxBytes,
+		Storage:     convertStorage(config.Storage),
+		Replicas:    config.Replicas,
+	}
+}
+
+func convertDurations(in *ObjectStoreConfig, out *nats.ObjectStoreConfig) error {
+	if in.TTL != "" {
+		ttl, err := time.ParseDuration(in.TTL)
+		if err != nil {
+			return err
+		}
+		out.TTL = ttl
+	}
+	return nil
+}
+
+func ConfigV1Alpha1ToNats(bucket string, config *ObjectStoreConfig) (*nats.ObjectStoreConfig, error) {
+	natsConfig := convertBase(bucket, config)
+	err := convertDurations(config, natsConfig)
+	if err != nil {
+		return &nats.ObjectStoreConfig{}, err
+	}
+
+	if config.Placement != nil {
+		natsConfig.Placement = &nats.Placement{
+			Cluster: config.Placement.Cluster,
+			Tags:    config.Placement.Tags,
+		}
+	}
+
+	return natsConfig, nil
+}
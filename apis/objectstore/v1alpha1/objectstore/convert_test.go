@@ -0,0 +1,55 @@
+package objectstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+func TestConvertToNats(t *testing.T) {
+	assert := assert.New(t)
+	ttl := "720h"
+
+	customConfig := &ObjectStoreConfig{
+		Description: "firmware images",
+		TTL:         ttl,
+		MaxBytes:    1073741824,
+		Storage:     "File",
+		Replicas:    3,
+		Placement: &stream.Placement{
+			Cluster: "mycluster",
+			Tags:    []string{"ssd"},
+		},
+	}
+
+	natsConfig, err := ConfigV1Alpha1ToNats("mybucket", customConfig)
+	assert.Nil(err)
+	assert.Equal(natsConfig.Bucket, "mybucket")
+	assert.Equal(natsConfig.Description, "firmware images")
+	assert.Equal(natsConfig.TTL, func() time.Duration {
+		t, _ := time.ParseDuration(ttl)
+		return t
+	}())
+	assert.Equal(natsConfig.MaxBytes, int64(1073741824))
+	assert.Equal(natsConfig.Storage, nats.FileStorage)
+	assert.Equal(natsConfig.Replicas, 3)
+	assert.Equal(natsConfig.Placement, &nats.Placement{
+		Cluster: "mycluster",
+		Tags:    []string{"ssd"},
+	})
+}
+
+func TestConvertToNatsInvalidTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	customConfig := &ObjectStoreConfig{
+		TTL: "foo",
+	}
+
+	_, err := ConfigV1Alpha1ToNats("mybucket", customConfig)
+	assert.NotNil(err)
+}
@@ -0,0 +1,8 @@
+package objectstore
+
+func (o *ObjectStoreConfig) SetDefaults() {
+	o.TTL = "0s"
+	o.MaxBytes = -1
+	o.Storage = "File"
+	o.Replicas = 1
+}
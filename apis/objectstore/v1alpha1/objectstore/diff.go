@@ -0,0 +1,28 @@
+package objectstore
+
+import (
+	"github.com/nats-io/nats.go"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// DiffNatsConfig returns the names of the fields of the desired bucket
+// configuration that differ from the observed one. Defaults the server and
+// nats.go apply to unset fields are not reported as differences.
+func DiffNatsConfig(desired *nats.ObjectStoreConfig, observed *nats.ObjectStoreConfig) []string {
+	diff := []string{}
+	add := func(field string, differs bool) {
+		if differs {
+			diff = append(diff, field)
+		}
+	}
+
+	add("Description", desired.Description != observed.Description)
+	add("TTL", desired.TTL != observed.TTL)
+	add("MaxBytes", !stream.EqualLimit(desired.MaxBytes, observed.MaxBytes))
+	add("Storage", desired.Storage != observed.Storage)
+	add("Replicas", desired.Replicas != 0 && desired.Replicas != observed.Replicas)
+	add("Placement", !stream.EqualPlacement(desired.Placement, observed.Placement))
+
+	return diff
+}
@@ -0,0 +1,49 @@
+package objectstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffNatsConfigServerDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := &nats.ObjectStoreConfig{
+		Bucket:    "mybucket",
+		MaxBytes:  0,
+		Replicas:  0,
+		Placement: &nats.Placement{},
+	}
+	observed := &nats.ObjectStoreConfig{
+		Bucket:   "mybucket",
+		MaxBytes: -1,
+		Replicas: 1,
+	}
+
+	assert.Empty(DiffNatsConfig(desired, observed))
+}
+
+func TestDiffNatsConfigChangedFields(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := &nats.ObjectStoreConfig{
+		Bucket:      "mybucket",
+		Description: "new",
+		TTL:         time.Hour,
+		MaxBytes:    1024,
+		Storage:     nats.MemoryStorage,
+		Replicas:    3,
+		Placement:   &nats.Placement{Cluster: "east"},
+	}
+	observed := &nats.ObjectStoreConfig{
+		Bucket:   "mybucket",
+		MaxBytes: -1,
+		Storage:  nats.FileStorage,
+		Replicas: 1,
+	}
+
+	assert.Equal([]string{"Description", "TTL", "MaxBytes", "Storage", "Replicas", "Placement"}, DiffNatsConfig(desired, observed))
+}
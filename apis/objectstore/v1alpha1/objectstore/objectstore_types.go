@@ -0,0 +1,73 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// +kubebuilder:object:generate=true
+// ObjectStoreConfig will determine the properties for a JetStream object store bucket.
+// For more information see https://docs.nats.io/nats-concepts/jetstream/obj_store
+type ObjectStoreConfig struct {
+	// Description is a human readable description of the bucket.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// TTL is the maximum age of an object in the bucket.
+	// Format is a string duration, e.g. 1h, 1m, 1s, 1h30m or 2h3m4s.
+	// +kubebuilder:validation:Pattern="([0-9]+h)?([0-9]+m)?([0-9]+s)?"
+	// +kubebuilder:default="0s"
+	// +kubebuilder:validation:Optional
+	TTL string `json:"ttl"`
+
+	// MaxBytes defines how many bytes the bucket may contain.
+	// +kubebuilder:default=-1
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:validation:Optional
+	MaxBytes int64 `json:"maxBytes"`
+
+	// Storage defines the storage type for the bucket data.
+	// +kubebuilder:validation:Enum=File;Memory
+	// +kubebuilder:default=File
+	Storage string `json:"storage"`
+
+	// Replicas defines how many replicas to keep for each object in a clustered JetStream.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +kubebuilder:validation:Optional
+	Replicas int `json:"replicas"`
+
+	// Placement is the placement policy for the bucket.
+	// +kubebuilder:validation:Optional
+	Placement *stream.Placement `json:"placement,omitempty"`
+}
+
+// ObjectStoreObservationState is the current state of an object store bucket.
+type ObjectStoreObservationState struct {
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Size is the combined size of all data in the bucket including metadata.
+	Size string `json:"size"`
+	// TTL is how long objects are kept in the bucket.
+	TTL string `json:"ttl,omitempty"`
+	// Sealed indicates whether the bucket is sealed and cannot be modified.
+	Sealed bool `json:"sealed,omitempty"`
+	// BackingStore indicates what technology is used for storage of the bucket.
+	BackingStore string `json:"backingStore,omitempty"`
+}
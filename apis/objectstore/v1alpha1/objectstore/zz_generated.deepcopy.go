@@ -0,0 +1,61 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package objectstore
+
+import (
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreConfig) DeepCopyInto(out *ObjectStoreConfig) {
+	*out = *in
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(stream.Placement)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreConfig.
+func (in *ObjectStoreConfig) DeepCopy() *ObjectStoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreObservationState) DeepCopyInto(out *ObjectStoreObservationState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreObservationState.
+func (in *ObjectStoreObservationState) DeepCopy() *ObjectStoreObservationState {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreObservationState)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// jsAPIPrefix is the JetStream API prefix of streams without a domain.
+const jsAPIPrefix = "$JS.API"
+
+// ResolveReferences of this Stream.
+// Referenced Streams set the name and, if they are in another domain or behind
+// another API prefix, the domain or external API prefix of the mirror and the
+// sources of the stream.
+func (mg *Stream) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+	p := mg.Spec.ForProvider
+
+	if mg.Spec.ForProvider.Config.Mirror != nil {
+		if err := resolveSource(ctx, c, r, p, mg.Spec.ForProvider.Config.Mirror); err != nil {
+			return errors.Wrap(err, "mg.Spec.ForProvider.Config.Mirror")
+		}
+	}
+
+	for i, source := range mg.Spec.ForProvider.Config.Sources {
+		if err := resolveSource(ctx, c, r, p, source); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("mg.Spec.ForProvider.Config.Sources[%d]", i))
+		}
+	}
+
+	return nil
+}
+
+func resolveSource(ctx context.Context, c client.Reader, r *reference.APIResolver, p StreamParameters, s *stream.StreamSource) error {
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: s.Name,
+		Reference:    s.NameRef,
+		Selector:     s.NameSelector,
+		To:           reference.To{Managed: &Stream{}, List: &StreamList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return err
+	}
+	s.Name = rsp.ResolvedValue
+	s.NameRef = rsp.ResolvedReference
+
+	if s.NameRef == nil || s.Domain != "" || s.External != nil {
+		return nil
+	}
+
+	origin := &Stream{}
+	if err := c.Get(ctx, types.NamespacedName{Name: s.NameRef.Name}, origin); err != nil {
+		return errors.Wrap(err, "cannot get referenced Stream")
+	}
+	o := origin.Spec.ForProvider
+	switch {
+	case o.APIPrefix != "":
+		if o.APIPrefix != p.APIPrefix {
+			s.External = &stream.ExternalStream{APIPrefix: o.APIPrefix}
+		}
+	case o.Domain == "":
+		// A source without a domain is only reachable through the JetStream
+		// API of the account from a stream in a domain or behind a prefix.
+		if p.Domain != "" || p.APIPrefix != "" {
+			s.External = &stream.ExternalStream{APIPrefix: jsAPIPrefix}
+		}
+	case o.Domain != p.Domain:
+		s.Domain = o.Domain
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// newOrigin returns a Stream of the NATS stream external in a domain or
+// through an API prefix.
+func newOrigin(name string, external string, domain string, apiPrefix string) *Stream {
+	s := &Stream{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"app": name}}}
+	s.Spec.ForProvider.Domain = domain
+	s.Spec.ForProvider.APIPrefix = apiPrefix
+	meta.SetExternalName(s, external)
+	return s
+}
+
+// streamClient returns a kube client that gets and lists the supplied Streams.
+func streamClient(streams ...*Stream) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			for _, s := range streams {
+				if s.GetName() == key.Name {
+					s.DeepCopyInto(obj.(*Stream))
+					return nil
+				}
+			}
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "streams"}, key.Name)
+		},
+		MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			o := &client.ListOptions{}
+			o.ApplyOptions(opts)
+			l := obj.(*StreamList)
+			for _, s := range streams {
+				if o.LabelSelector == nil || o.LabelSelector.Matches(labels.Set(s.GetLabels())) {
+					l.Items = append(l.Items, *s)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestResolveReferences(t *testing.T) {
+	kube := streamClient(
+		newOrigin("orders-hub", "orders", "hub", ""),
+		newOrigin("invoices-leaf", "invoices", "leaf", ""),
+		newOrigin("payments", "payments", "", ""),
+		newOrigin("payments-tenant", "payments", "", "$JS.tenant.API"),
+	)
+
+	tests := []struct {
+		name      string
+		domain    string
+		apiPrefix string
+		mirror    *stream.StreamSource
+		sources   []*stream.StreamSource
+		want      []stream.StreamSource
+		err       bool
+	}{
+		{
+			name:    "name without reference",
+			sources: []*stream.StreamSource{{Name: "payments"}},
+			want:    []stream.StreamSource{{Name: "payments"}},
+		},
+		{
+			name:   "mirror reference in the same domain",
+			domain: "hub",
+			mirror: &stream.StreamSource{NameRef: &xpv1.Reference{Name: "orders-hub"}},
+			want:   []stream.StreamSource{{Name: "orders", NameRef: &xpv1.Reference{Name: "orders-hub"}}},
+		},
+		{
+			name:   "sources in other domains",
+			domain: "hub",
+			sources: []*stream.StreamSource{
+				{NameRef: &xpv1.Reference{Name: "orders-hub"}},
+				{NameRef: &xpv1.Reference{Name: "invoices-leaf"}},
+			},
+			want: []stream.StreamSource{
+				{Name: "orders", NameRef: &xpv1.Reference{Name: "orders-hub"}},
+				{Name: "invoices", NameRef: &xpv1.Reference{Name: "invoices-leaf"}, Domain: "leaf"},
+			},
+		},
+		{
+			name:    "source keeps domain",
+			sources: []*stream.StreamSource{{NameRef: &xpv1.Reference{Name: "invoices-leaf"}, Domain: "edge"}},
+			want:    []stream.StreamSource{{Name: "invoices", NameRef: &xpv1.Reference{Name: "invoices-leaf"}, Domain: "edge"}},
+		},
+		{
+			name:    "external source keeps no domain",
+			sources: []*stream.StreamSource{{NameRef: &xpv1.Reference{Name: "invoices-leaf"}, External: &stream.ExternalStream{APIPrefix: "$JS.leaf.API"}}},
+			want:    []stream.StreamSource{{Name: "invoices", NameRef: &xpv1.Reference{Name: "invoices-leaf"}, External: &stream.ExternalStream{APIPrefix: "$JS.leaf.API"}}},
+		},
+		{
+			name:    "source without domain",
+			domain:  "hub",
+			sources: []*stream.StreamSource{{NameRef: &xpv1.Reference{Name: "payments"}}},
+			want:    []stream.StreamSource{{Name: "payments", NameRef: &xpv1.Reference{Name: "payments"}, External: &stream.ExternalStream{APIPrefix: "$JS.API"}}},
+		},
+		{
+			name:    "source without domain in the same domain",
+			sources: []*stream.StreamSource{{NameRef: &xpv1.Reference{Name: "payments"}}},
+			want:    []stream.StreamSource{{Name: "payments", NameRef: &xpv1.Reference{Name: "payments"}}},
+		},
+		{
+			name:      "source without domain from a stream with API prefix",
+			apiPrefix: "$JS.tenant.API",
+			sources:   []*stream.StreamSource{{NameRef: &xpv1.Reference{Name: "payments"}}},
+			want:      []stream.StreamSource{{Name: "payments", NameRef: &xpv1.Reference{Name: "payments"}, External: &stream.ExternalStream{APIPrefix: "$JS.API"}}},
+		},
+		{
+			name:   "mirror with API prefix",
+			domain: "hub",
+			mirror: &stream.StreamSource{NameRef: &xpv1.Reference{Name: "payments-tenant"}},
+			want:   []stream.StreamSource{{Name: "payments", NameRef: &xpv1.Reference{Name: "payments-tenant"}, External: &stream.ExternalStream{APIPrefix: "$JS.tenant.API"}}},
+		},
+		{
+			name:      "source with the same API prefix",
+			apiPrefix: "$JS.tenant.API",
+			sources:   []*stream.StreamSource{{NameRef: &xpv1.Reference{Name: "payments-tenant"}}},
+			want:      []stream.StreamSource{{Name: "payments", NameRef: &xpv1.Reference{Name: "payments-tenant"}}},
+		},
+		{
+			name:    "selector",
+			domain:  "hub",
+			sources: []*stream.StreamSource{{NameSelector: &xpv1.Selector{MatchLabels: map[string]string{"app": "invoices-leaf"}}}},
+			want: []stream.StreamSource{{
+				Name:         "invoices",
+				NameRef:      &xpv1.Reference{Name: "invoices-leaf"},
+				NameSelector: &xpv1.Selector{MatchLabels: map[string]string{"app": "invoices-leaf"}},
+				Domain:       "leaf",
+			}},
+		},
+		{
+			name:    "missing reference",
+			sources: []*stream.StreamSource{{NameRef: &xpv1.Reference{Name: "missing"}}},
+			err:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := &Stream{}
+			mg.Spec.ForProvider.Domain = tt.domain
+			mg.Spec.ForProvider.APIPrefix = tt.apiPrefix
+			mg.Spec.ForProvider.Config.Mirror = tt.mirror
+			mg.Spec.ForProvider.Config.Sources = tt.sources
+			err := mg.ResolveReferences(context.Background(), kube)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			got := []stream.StreamSource{}
+			if m := mg.Spec.ForProvider.Config.Mirror; m != nil {
+				got = append(got, *m)
+			}
+			for _, s := range mg.Spec.ForProvider.Config.Sources {
+				got = append(got, *s)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package stream
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/nats-io/nats.go"
 
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
 	convert "github.com/edgefarm/provider-nats/internal/convert"
 )
 
@@ -43,7 +47,19 @@ func convertStorage(storage string) nats.StorageType {
 	}
 }
 
-func convertBase(name string, config *StreamConfig) *nats.StreamConfig {
+func convertBase(name string, config *StreamConfig) (*nats.StreamConfig, error) {
+	maxBytes, err := config.MaxBytes.Value()
+	if err != nil {
+		return nil, fmt.Errorf("maxBytes: %w", err)
+	}
+	maxMsgSize, err := config.MaxMsgSize.Value()
+	if err != nil {
+		return nil, fmt.Errorf("maxMsgSize: %w", err)
+	}
+	if maxMsgSize > math.MaxInt32 {
+		return nil, fmt.Errorf("maxMsgSize: %s exceeds the maximum message size", config.MaxMsgSize)
+	}
+
 	return &nats.StreamConfig{
 		Name:                 name,
 		Description:          config.Description,
@@ -51,11 +67,11 @@ func convertBase(name string, config *StreamConfig) *nats.StreamConfig {
 		Retention:            convertRetentionPolicy(config.Retention),
 		MaxConsumers:         config.MaxConsumers,
 		MaxMsgs:              config.MaxMsgs,
-		MaxBytes:             config.MaxBytes,
+		MaxBytes:             maxBytes,
 		Discard:              convertDiscardPolicy(config.Discard),
 		DiscardNewPerSubject: config.DiscardNewPerSubject,
 		MaxMsgsPerSubject:    config.MaxMsgsPerSubject,
-		MaxMsgSize:           config.MaxMsgSize,
+		MaxMsgSize:           int32(maxMsgSize),
 		Storage:              convertStorage(config.Storage),
 		Replicas:             config.Replicas,
 		NoAck:                config.NoAck,
@@ -66,7 +82,7 @@ func convertBase(name string, config *StreamConfig) *nats.StreamConfig {
 		AllowRollup:          config.AllowRollup,
 		AllowDirect:          config.AllowDirect,
 		MirrorDirect:         config.MirrorDirect,
-	}
+	}, nil
 }
 
 func convertDurations(in *StreamConfig, out *nats.StreamConfig) error {
@@ -88,28 +104,37 @@ func convertDurations(in *StreamConfig, out *nats.StreamConfig) error {
 	return nil
 }
 
-func convertMirror(in *StreamConfig, out *nats.StreamConfig) error {
-	if in.Mirror != nil {
-		var optStartTime *time.Time
-		if in.Mirror.StartTime != "" {
-			var err error
-			optStartTime, err = convert.RFC3339ToTime(in.Mirror.StartTime)
-			if err != nil {
-				return err
-			}
+// ConvertStreamSource converts a v1alpha1 StreamSource to a NATS StreamSource.
+func ConvertStreamSource(in *StreamSource) (*nats.StreamSource, error) {
+	var optStartTime *time.Time
+	if in.StartTime != "" {
+		var err error
+		optStartTime, err = convert.RFC3339ToTime(in.StartTime)
+		if err != nil {
+			return nil, err
 		}
-		mirrorConfig := &nats.StreamSource{
-			Name:          in.Mirror.Name,
-			OptStartSeq:   in.Mirror.StartSeq,
-			OptStartTime:  optStartTime,
-			FilterSubject: in.Mirror.FilterSubject,
-			Domain:        in.Mirror.Domain,
+	}
+	out := &nats.StreamSource{
+		Name:          in.Name,
+		OptStartSeq:   in.StartSeq,
+		OptStartTime:  optStartTime,
+		FilterSubject: in.FilterSubject,
+		Domain:        in.Domain,
+	}
+	if in.External != nil {
+		out.External = &nats.ExternalStream{
+			APIPrefix:     in.External.APIPrefix,
+			DeliverPrefix: in.External.DeliverPrefix,
 		}
-		if in.Mirror.External != nil {
-			mirrorConfig.External = &nats.ExternalStream{
-				APIPrefix:     in.Mirror.External.APIPrefix,
-				DeliverPrefix: in.Mirror.External.DeliverPrefix,
-			}
+	}
+	return out, nil
+}
+
+func convertMirror(in *StreamConfig, out *nats.StreamConfig) error {
+	if in.Mirror != nil {
+		mirrorConfig, err := ConvertStreamSource(in.Mirror)
+		if err != nil {
+			return err
 		}
 		out.Mirror = mirrorConfig
 	}
@@ -120,26 +145,9 @@ func convertSources(in *StreamConfig, out *nats.StreamConfig) error {
 	if in.Sources != nil {
 		sources := []*nats.StreamSource{}
 		for _, source := range in.Sources {
-			var optStartTime *time.Time
-			if source.StartTime != "" {
-				var err error
-				optStartTime, err = convert.RFC3339ToTime(source.StartTime)
-				if err != nil {
-					return err
-				}
-			}
-			streamSource := &nats.StreamSource{
-				Name:          source.Name,
-				OptStartSeq:   source.StartSeq,
-				OptStartTime:  optStartTime,
-				FilterSubject: source.FilterSubject,
-				Domain:        source.Domain,
-			}
-			if source.External != nil {
-				streamSource.External = &nats.ExternalStream{
-					APIPrefix:     source.External.APIPrefix,
-					DeliverPrefix: source.External.DeliverPrefix,
-				}
+			streamSource, err := ConvertStreamSource(source)
+			if err != nil {
+				return err
 			}
 			sources = append(sources, streamSource)
 		}
@@ -149,8 +157,11 @@ func convertSources(in *StreamConfig, out *nats.StreamConfig) error {
 }
 
 func ConfigV1Alpha1ToNats(name string, config *StreamConfig) (*nats.StreamConfig, error) {
-	natsConfig := convertBase(name, config)
-	err := convertDurations(config, natsConfig)
+	natsConfig, err := convertBase(name, config)
+	if err != nil {
+		return &nats.StreamConfig{}, err
+	}
+	err = convertDurations(config, natsConfig)
 	if err != nil {
 		return &nats.StreamConfig{}, err
 	}
@@ -194,3 +205,136 @@ func ConvertPeerInfo(peer *nats.PeerInfo) *PeerInfo {
 		Lag:     peer.Lag,
 	}
 }
+
+func convertRetentionPolicyToV1Alpha1(retention nats.RetentionPolicy) string {
+	switch retention {
+	case nats.InterestPolicy:
+		return "Interest"
+	case nats.WorkQueuePolicy:
+		return "WorkQueue"
+	default:
+		return "Limits"
+	}
+}
+
+func convertDiscardPolicyToV1Alpha1(discard nats.DiscardPolicy) string {
+	switch discard {
+	case nats.DiscardNew:
+		return "New"
+	default:
+		return "Old"
+	}
+}
+
+func convertStorageToV1Alpha1(storage nats.StorageType) string {
+	switch storage {
+	case nats.MemoryStorage:
+		return "Memory"
+	default:
+		return "File"
+	}
+}
+
+// domainOf returns the domain of an external stream configuration that only
+// consists of the API prefix of a domain.
+func domainOf(e *nats.ExternalStream) string {
+	if e.DeliverPrefix != "" {
+		return ""
+	}
+	domain := strings.TrimSuffix(strings.TrimPrefix(e.APIPrefix, "$JS."), ".API")
+	if domain == "" || fmt.Sprintf(extDomainAPIPrefix, domain) != e.APIPrefix {
+		return ""
+	}
+	return domain
+}
+
+// ConvertStreamSourceToV1Alpha1 converts a NATS StreamSource to a v1alpha1 StreamSource.
+// An external API prefix pointing to a domain is converted back to the domain.
+func ConvertStreamSourceToV1Alpha1(in *nats.StreamSource) (*StreamSource, error) {
+	out := &StreamSource{
+		Name:          in.Name,
+		StartSeq:      in.OptStartSeq,
+		FilterSubject: in.FilterSubject,
+		Domain:        in.Domain,
+	}
+	if in.OptStartTime != nil {
+		startTime, err := convert.TimeToRFC3339(in.OptStartTime)
+		if err != nil {
+			return nil, err
+		}
+		out.StartTime = startTime
+	}
+	if in.External != nil {
+		if domain := domainOf(in.External); domain != "" && out.Domain == "" {
+			out.Domain = domain
+		} else {
+			out.External = &ExternalStream{
+				APIPrefix:     in.External.APIPrefix,
+				DeliverPrefix: in.External.DeliverPrefix,
+			}
+		}
+	}
+	return out, nil
+}
+
+// ConfigNatsToV1Alpha1 converts a NATS stream configuration to a v1alpha1 StreamConfig.
+// It is the inverse of ConfigV1Alpha1ToNats.
+func ConfigNatsToV1Alpha1(config *nats.StreamConfig) (*StreamConfig, error) {
+	out := &StreamConfig{
+		Description:          config.Description,
+		Subjects:             config.Subjects,
+		Retention:            convertRetentionPolicyToV1Alpha1(config.Retention),
+		MaxConsumers:         config.MaxConsumers,
+		MaxMsgs:              config.MaxMsgs,
+		MaxBytes:             apisv1alpha1.NewByteQuantity(config.MaxBytes),
+		Discard:              convertDiscardPolicyToV1Alpha1(config.Discard),
+		DiscardNewPerSubject: config.DiscardNewPerSubject,
+		MaxAge:               config.MaxAge.String(),
+		MaxMsgsPerSubject:    config.MaxMsgsPerSubject,
+		MaxMsgSize:           apisv1alpha1.NewByteQuantity(int64(config.MaxMsgSize)),
+		Storage:              convertStorageToV1Alpha1(config.Storage),
+		Replicas:             config.Replicas,
+		NoAck:                config.NoAck,
+		TemplateOwner:        config.Template,
+		Duplicates:           config.Duplicates.String(),
+		Sealed:               config.Sealed,
+		DenyDelete:           config.DenyDelete,
+		DenyPurge:            config.DenyPurge,
+		AllowRollup:          config.AllowRollup,
+		AllowDirect:          config.AllowDirect,
+		MirrorDirect:         config.MirrorDirect,
+	}
+
+	if config.Placement != nil {
+		out.Placement = &Placement{
+			Cluster: config.Placement.Cluster,
+			Tags:    config.Placement.Tags,
+		}
+	}
+
+	if config.RePublish != nil {
+		out.RePublish = &RePublish{
+			Source:      config.RePublish.Source,
+			Destination: config.RePublish.Destination,
+			HeadersOnly: config.RePublish.HeadersOnly,
+		}
+	}
+
+	if config.Mirror != nil {
+		mirror, err := ConvertStreamSourceToV1Alpha1(config.Mirror)
+		if err != nil {
+			return nil, err
+		}
+		out.Mirror = mirror
+	}
+
+	for _, source := range config.Sources {
+		streamSource, err := ConvertStreamSourceToV1Alpha1(source)
+		if err != nil {
+			return nil, err
+		}
+		out.Sources = append(out.Sources, streamSource)
+	}
+
+	return out, nil
+}
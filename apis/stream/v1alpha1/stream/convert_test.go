@@ -6,6 +6,8 @@ import (
 
 	"github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/assert"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
 )
 
 func TestConvertToNats(t *testing.T) {
@@ -24,12 +26,12 @@ func TestConvertToNats(t *testing.T) {
 		Retention:            "Limits",
 		MaxConsumers:         2,
 		MaxMsgs:              100,
-		MaxBytes:             1024,
+		MaxBytes:             apisv1alpha1.NewByteQuantity(1024),
 		Discard:              "New",
 		DiscardNewPerSubject: false,
 		MaxAge:               maxAge,
 		MaxMsgsPerSubject:    -1,
-		MaxMsgSize:           -1,
+		MaxMsgSize:           apisv1alpha1.NewByteQuantity(-1),
 		Duplicates:           duplicates,
 		Storage:              "File",
 		Replicas:             1,
@@ -106,3 +108,36 @@ func TestConvertToNats(t *testing.T) {
 	assert.Equal(natsConfig.AllowDirect, false)
 	assert.Equal(natsConfig.MirrorDirect, false)
 }
+
+func TestConvertToNatsByteQuantities(t *testing.T) {
+	assert := assert.New(t)
+
+	natsConfig, err := ConfigV1Alpha1ToNats("mystream", &StreamConfig{
+		MaxBytes:   "1Gi",
+		MaxMsgSize: "1KiB",
+	})
+	assert.Nil(err)
+	assert.Equal(natsConfig.MaxBytes, int64(1<<30))
+	assert.Equal(natsConfig.MaxMsgSize, int32(1024))
+
+	natsConfig, err = ConfigV1Alpha1ToNats("mystream", &StreamConfig{
+		MaxBytes:   "1MB",
+		MaxMsgSize: "1k",
+	})
+	assert.Nil(err)
+	assert.Equal(natsConfig.MaxBytes, int64(1000000))
+	assert.Equal(natsConfig.MaxMsgSize, int32(1000))
+}
+
+func TestConvertToNatsInvalidByteQuantities(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := ConfigV1Alpha1ToNats("mystream", &StreamConfig{MaxBytes: "lots"})
+	assert.NotNil(err)
+
+	_, err = ConfigV1Alpha1ToNats("mystream", &StreamConfig{MaxMsgSize: "abc"})
+	assert.NotNil(err)
+
+	_, err = ConfigV1Alpha1ToNats("mystream", &StreamConfig{MaxMsgSize: "4Gi"})
+	assert.NotNil(err)
+}
@@ -1,5 +1,9 @@
 package stream
 
+import (
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
 func (s *StreamConfig) SetDefaults() {
 	s.Retention = "Limits"
 	s.Discard = "DiscardOld"
@@ -7,8 +11,8 @@ func (s *StreamConfig) SetDefaults() {
 	s.Replicas = 1
 	s.MaxConsumers = -1
 	s.MaxMsgs = -1
-	s.MaxBytes = -1
-	s.MaxMsgSize = -1
+	s.MaxBytes = apisv1alpha1.NewByteQuantity(-1)
+	s.MaxMsgSize = apisv1alpha1.NewByteQuantity(-1)
 	s.MaxAge = "0s"
 	s.MaxMsgsPerSubject = -1
 	s.Duplicates = "2m0s"
@@ -0,0 +1,153 @@
+package stream
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	extDomainAPIPrefix = "$JS.%s.API"
+)
+
+// DiffNatsConfig returns the names of the fields of the desired stream configuration
+// that differ from the observed one. Fields left unset in the desired configuration
+// that the server populates with defaults are not reported.
+func DiffNatsConfig(desired *nats.StreamConfig, observed *nats.StreamConfig) []string {
+	diff := []string{}
+	add := func(field string, differs bool) {
+		if differs {
+			diff = append(diff, field)
+		}
+	}
+
+	add("Description", desired.Description != observed.Description)
+	add("Subjects", !equalStringSet(desired.Subjects, observed.Subjects))
+	add("Retention", desired.Retention != observed.Retention)
+	add("MaxConsumers", !EqualLimit(int64(desired.MaxConsumers), int64(observed.MaxConsumers)))
+	add("MaxMsgs", !EqualLimit(desired.MaxMsgs, observed.MaxMsgs))
+	add("MaxBytes", !EqualLimit(desired.MaxBytes, observed.MaxBytes))
+	add("Discard", desired.Discard != observed.Discard)
+	add("DiscardNewPerSubject", desired.DiscardNewPerSubject != observed.DiscardNewPerSubject)
+	add("MaxAge", desired.MaxAge != observed.MaxAge)
+	add("MaxMsgsPerSubject", !EqualLimit(desired.MaxMsgsPerSubject, observed.MaxMsgsPerSubject))
+	add("MaxMsgSize", !EqualLimit(int64(desired.MaxMsgSize), int64(observed.MaxMsgSize)))
+	add("Storage", desired.Storage != observed.Storage)
+	add("Replicas", desired.Replicas != 0 && desired.Replicas != observed.Replicas)
+	add("NoAck", desired.NoAck != observed.NoAck)
+	add("Template", desired.Template != observed.Template)
+	add("Duplicates", desired.Duplicates != 0 && desired.Duplicates != observed.Duplicates)
+	add("Placement", !EqualPlacement(desired.Placement, observed.Placement))
+	add("Mirror", !EqualStreamSource(desired.Mirror, observed.Mirror))
+	add("Sources", !EqualStreamSources(desired.Sources, observed.Sources))
+	add("Sealed", desired.Sealed != observed.Sealed)
+	add("DenyDelete", desired.DenyDelete != observed.DenyDelete)
+	add("DenyPurge", desired.DenyPurge != observed.DenyPurge)
+	add("AllowRollup", desired.AllowRollup != observed.AllowRollup)
+	add("RePublish", !EqualRePublish(desired.RePublish, observed.RePublish))
+	add("AllowDirect", desired.AllowDirect != observed.AllowDirect)
+	add("MirrorDirect", desired.MirrorDirect != observed.MirrorDirect)
+
+	return diff
+}
+
+// EqualLimit reports whether two limits are equal, treating zero and negative
+// limits as unlimited as the server does.
+func EqualLimit(desired int64, observed int64) bool {
+	if desired <= 0 && observed <= 0 {
+		return true
+	}
+	return desired == observed
+}
+
+func equalStringSet(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string{}, a...)
+	sb := append([]string{}, b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualPlacement reports whether two placements are equal, treating a missing
+// placement as empty.
+func EqualPlacement(a *nats.Placement, b *nats.Placement) bool {
+	if a == nil {
+		a = &nats.Placement{}
+	}
+	if b == nil {
+		b = &nats.Placement{}
+	}
+	return a.Cluster == b.Cluster && equalStringSet(a.Tags, b.Tags)
+}
+
+// EqualRePublish reports whether two republish configurations are equal,
+// treating a missing configuration as empty.
+func EqualRePublish(a *nats.RePublish, b *nats.RePublish) bool {
+	if a == nil {
+		a = &nats.RePublish{}
+	}
+	if b == nil {
+		b = &nats.RePublish{}
+	}
+	return *a == *b
+}
+
+// externalOf returns the external stream configuration of a source. A source
+// domain is sent to the server as the API prefix of the domain.
+func externalOf(s *nats.StreamSource) nats.ExternalStream {
+	if s.External != nil {
+		return *s.External
+	}
+	if s.Domain != "" {
+		return nats.ExternalStream{APIPrefix: fmt.Sprintf(extDomainAPIPrefix, s.Domain)}
+	}
+	return nats.ExternalStream{}
+}
+
+// EqualStreamSource reports whether two sources are equal. A source domain
+// equals the API prefix of the domain.
+func EqualStreamSource(a *nats.StreamSource, b *nats.StreamSource) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if a.Name != b.Name || a.OptStartSeq != b.OptStartSeq || a.FilterSubject != b.FilterSubject {
+		return false
+	}
+	if (a.OptStartTime == nil) != (b.OptStartTime == nil) {
+		return false
+	}
+	if a.OptStartTime != nil && !a.OptStartTime.Equal(*b.OptStartTime) {
+		return false
+	}
+	return externalOf(a) == externalOf(b)
+}
+
+// EqualStreamSources reports whether two lists of sources are equal regardless
+// of their order.
+func EqualStreamSources(a []*nats.StreamSource, b []*nats.StreamSource) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, sa := range a {
+		found := false
+		for _, sb := range b {
+			if EqualStreamSource(sa, sb) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
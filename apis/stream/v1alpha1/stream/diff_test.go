@@ -0,0 +1,88 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffNatsConfigServerDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := &nats.StreamConfig{
+		Name:       "mystream",
+		Subjects:   []string{"foo", "bar"},
+		MaxMsgs:    -1,
+		MaxBytes:   0,
+		MaxMsgSize: 0,
+		Replicas:   0,
+		Mirror:     nil,
+		Placement:  &nats.Placement{},
+		RePublish:  nil,
+	}
+	observed := &nats.StreamConfig{
+		Name:       "mystream",
+		Subjects:   []string{"bar", "foo"},
+		MaxMsgs:    -1,
+		MaxBytes:   -1,
+		MaxMsgSize: -1,
+		Replicas:   1,
+		Duplicates: 2 * time.Minute,
+	}
+
+	assert.Empty(DiffNatsConfig(desired, observed))
+}
+
+func TestDiffNatsConfigChangedFields(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := &nats.StreamConfig{
+		Name:     "mystream",
+		Subjects: []string{"foo"},
+		MaxAge:   time.Hour,
+		MaxBytes: 1024,
+	}
+	observed := &nats.StreamConfig{
+		Name:     "mystream",
+		Subjects: []string{"foo", "bar"},
+		MaxAge:   time.Minute,
+		MaxBytes: -1,
+	}
+
+	assert.Equal([]string{"Subjects", "MaxBytes", "MaxAge"}, DiffNatsConfig(desired, observed))
+}
+
+func TestDiffNatsConfigSourceDomain(t *testing.T) {
+	assert := assert.New(t)
+
+	desired := &nats.StreamConfig{
+		Name: "aggregate",
+		Sources: []*nats.StreamSource{
+			{Name: "foo", Domain: "foo"},
+			{Name: "bar", Domain: "bar"},
+		},
+	}
+	observed := &nats.StreamConfig{
+		Name: "aggregate",
+		Sources: []*nats.StreamSource{
+			{Name: "bar", External: &nats.ExternalStream{APIPrefix: "$JS.bar.API"}},
+			{Name: "foo", External: &nats.ExternalStream{APIPrefix: "$JS.foo.API"}},
+		},
+	}
+	assert.Empty(DiffNatsConfig(desired, observed))
+
+	observed.Sources[0].FilterSubject = "bar.>"
+	assert.Equal([]string{"Sources"}, DiffNatsConfig(desired, observed))
+}
+
+func TestEqualLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(EqualLimit(0, -1))
+	assert.True(EqualLimit(-1, 0))
+	assert.True(EqualLimit(1024, 1024))
+	assert.False(EqualLimit(1024, -1))
+	assert.False(EqualLimit(0, 1024))
+}
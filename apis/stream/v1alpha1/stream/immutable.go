@@ -0,0 +1,32 @@
+package stream
+
+import (
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// UpdatePolicyReject blocks changes to immutable fields of a stream.
+	UpdatePolicyReject = "Reject"
+	// UpdatePolicyRecreate deletes and recreates a stream when immutable fields change.
+	UpdatePolicyRecreate = "Recreate"
+	// UpdatePolicyRecreateWithBackup snapshots a stream and its consumers before recreating it.
+	UpdatePolicyRecreateWithBackup = "RecreateWithBackup"
+)
+
+// ImmutableDiff returns the names of the fields of the desired stream configuration
+// that differ from the observed one and cannot be changed by updating the stream.
+func ImmutableDiff(desired *nats.StreamConfig, observed *nats.StreamConfig) []string {
+	diff := []string{}
+	add := func(field string, differs bool) {
+		if differs {
+			diff = append(diff, field)
+		}
+	}
+
+	add("Storage", desired.Storage != observed.Storage)
+	add("Retention", desired.Retention != observed.Retention)
+	add("Template", desired.Template != observed.Template)
+	add("Mirror", !EqualStreamSource(desired.Mirror, observed.Mirror))
+
+	return diff
+}
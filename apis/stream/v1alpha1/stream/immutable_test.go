@@ -0,0 +1,31 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImmutableDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	observed := &nats.StreamConfig{
+		Name:      "mystream",
+		Subjects:  []string{"foo"},
+		Retention: nats.LimitsPolicy,
+		Storage:   nats.FileStorage,
+		MaxMsgs:   100,
+	}
+
+	desired := *observed
+	desired.MaxMsgs = 200
+	desired.Subjects = []string{"foo", "bar"}
+	assert.Empty(ImmutableDiff(&desired, observed))
+
+	desired = *observed
+	desired.Storage = nats.MemoryStorage
+	desired.Retention = nats.WorkQueuePolicy
+	desired.Mirror = &nats.StreamSource{Name: "other"}
+	assert.Equal([]string{"Storage", "Retention", "Mirror"}, ImmutableDiff(&desired, observed))
+}
@@ -0,0 +1,39 @@
+package stream
+
+import (
+	"github.com/nats-io/nats.go"
+)
+
+// LateInitialize sets the fields of the stream configuration that were left
+// unset and are populated with defaults by the server to the observed values.
+// It returns true if any field was set.
+func LateInitialize(in *StreamConfig, observed *nats.StreamConfig) (bool, error) {
+	from, err := ConfigNatsToV1Alpha1(observed)
+	if err != nil {
+		return false, err
+	}
+
+	li := false
+	lateInit(&in.Retention, from.Retention, &li)
+	lateInit(&in.MaxConsumers, from.MaxConsumers, &li)
+	lateInit(&in.MaxMsgs, from.MaxMsgs, &li)
+	lateInit(&in.MaxBytes, from.MaxBytes, &li)
+	lateInit(&in.Discard, from.Discard, &li)
+	lateInit(&in.MaxAge, from.MaxAge, &li)
+	lateInit(&in.MaxMsgsPerSubject, from.MaxMsgsPerSubject, &li)
+	lateInit(&in.MaxMsgSize, from.MaxMsgSize, &li)
+	lateInit(&in.Storage, from.Storage, &li)
+	lateInit(&in.Replicas, from.Replicas, &li)
+	lateInit(&in.Duplicates, from.Duplicates, &li)
+
+	return li, nil
+}
+
+// lateInit sets in to from if in has its zero value.
+func lateInit[T comparable](in *T, from T, li *bool) {
+	var zero T
+	if *in == zero && from != zero {
+		*in = from
+		*li = true
+	}
+}
@@ -0,0 +1,96 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+func TestConvertNatsToV1Alpha1RoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	optStartTime := time.Date(2023, 1, 9, 14, 48, 32, 0, time.UTC)
+
+	natsConfig := &nats.StreamConfig{
+		Name:              "mystream",
+		Description:       "this is a test stream",
+		Subjects:          []string{"foo", "bar"},
+		Retention:         nats.WorkQueuePolicy,
+		MaxConsumers:      -1,
+		MaxMsgs:           100,
+		MaxBytes:          1024,
+		Discard:           nats.DiscardNew,
+		MaxAge:            time.Hour,
+		MaxMsgsPerSubject: -1,
+		MaxMsgSize:        -1,
+		Storage:           nats.MemoryStorage,
+		Replicas:          3,
+		Duplicates:        2 * time.Minute,
+		Placement:         &nats.Placement{Cluster: "mycluster", Tags: []string{"a"}},
+		Sources: []*nats.StreamSource{
+			{
+				Name:         "origin",
+				OptStartTime: &optStartTime,
+				External:     &nats.ExternalStream{APIPrefix: "$JS.mydomain.API"},
+			},
+			{
+				Name:     "other",
+				External: &nats.ExternalStream{APIPrefix: "$JS.ACC.API", DeliverPrefix: "deliver"},
+			},
+		},
+	}
+
+	config, err := ConfigNatsToV1Alpha1(natsConfig)
+	assert.Nil(err)
+	assert.Equal("WorkQueue", config.Retention)
+	assert.Equal("New", config.Discard)
+	assert.Equal("Memory", config.Storage)
+	assert.Equal("1h0m0s", config.MaxAge)
+	assert.Equal("2m0s", config.Duplicates)
+	assert.Equal("mydomain", config.Sources[0].Domain)
+	assert.Nil(config.Sources[0].External)
+	assert.Equal("2023-01-09T14:48:32Z", config.Sources[0].StartTime)
+	assert.Equal("", config.Sources[1].Domain)
+	assert.Equal("$JS.ACC.API", config.Sources[1].External.APIPrefix)
+
+	converted, err := ConfigV1Alpha1ToNats("mystream", config)
+	assert.Nil(err)
+	assert.Empty(DiffNatsConfig(converted, natsConfig))
+}
+
+func TestLateInitialize(t *testing.T) {
+	assert := assert.New(t)
+
+	observed := &nats.StreamConfig{
+		Name:              "mystream",
+		Retention:         nats.LimitsPolicy,
+		MaxConsumers:      -1,
+		MaxMsgs:           -1,
+		MaxBytes:          -1,
+		MaxMsgsPerSubject: -1,
+		MaxMsgSize:        -1,
+		Storage:           nats.FileStorage,
+		Replicas:          1,
+		Duplicates:        2 * time.Minute,
+	}
+
+	config := &StreamConfig{
+		Subjects: []string{"foo"},
+		MaxBytes: "1024",
+	}
+	li, err := LateInitialize(config, observed)
+	assert.Nil(err)
+	assert.True(li)
+	assert.Equal(apisv1alpha1.ByteQuantity("1024"), config.MaxBytes)
+	assert.Equal(apisv1alpha1.ByteQuantity("-1"), config.MaxMsgSize)
+	assert.Equal("2m0s", config.Duplicates)
+	assert.Equal(1, config.Replicas)
+	assert.Equal("Limits", config.Retention)
+
+	li, err = LateInitialize(config, observed)
+	assert.Nil(err)
+	assert.False(li)
+}
@@ -16,6 +16,12 @@ limitations under the License.
 
 package stream
 
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
 // +kubebuilder:object:generate=true
 // StreamConfig will determine the properties for a stream.
 // There are sensible defaults for most.
@@ -46,11 +52,12 @@ type StreamConfig struct {
 	// +kubebuilder:validation:Required
 	MaxMsgs int64 `json:"maxMsgs"`
 
-	// MaxBytes defines how many bytes the Stream may contain.
+	// MaxBytes defines how many bytes the Stream may contain, e.g. 1073741824, 1Gi or 1GB.
 	// Adheres to Discard Policy, removing oldest or refusing new messages if the Stream exceeds this size.
+	// Must be -1 for unlimited or greater, which is checked by the webhook as the size may be a string.
 	// +kubebuilder:default=-1
 	// +kubebuilder:validation:Required
-	MaxBytes int64 `json:"maxBytes"`
+	MaxBytes apisv1alpha1.ByteQuantity `json:"maxBytes"`
 
 	// Discard defines the behavior of discarding messages when any streams' limits have been reached.
 	// Old (default): This policy will delete the oldest messages in order to maintain the limit. For example, if MaxAge is set to one minute, the server will automatically delete messages older than one minute with this policy.
@@ -78,11 +85,11 @@ type StreamConfig struct {
 	// +kubebuilder:validation:Optional
 	MaxMsgsPerSubject int64 `json:"maxMsgsPerSubject"`
 
-	// MaxBytesPerSubject defines the largest message that will be accepted by the Stream.
+	// MaxMsgSize defines the largest message that will be accepted by the Stream, e.g. 1048576, 1Mi or 1MB.
+	// Must be -1 for unlimited or between 0 and 2147483647, which is checked by the webhook as the size may be a string.
 	// +kubebuilder:default=-1
-	// +kubebuilder:validation:Minimum=-1
 	// +kubebuilder:validation:Optional
-	MaxMsgSize int32 `json:"maxMsgSize"`
+	MaxMsgSize apisv1alpha1.ByteQuantity `json:"maxMsgSize"`
 
 	// Storage defines the storage type for stream data..
 	// +kubebuilder:validation:Enum=File;Memory
@@ -182,7 +189,17 @@ type Placement struct {
 // StreamSource dictates how streams can source from other streams.
 type StreamSource struct {
 	// Name of the origin stream to source messages from.
-	Name string `json:"name"`
+	// Either Name, NameRef or NameSelector must be set.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+
+	// NameRef references a Stream to set Name and, if the Stream is in another domain, Domain of the source.
+	// +kubebuilder:validation:Optional
+	NameRef *xpv1.Reference `json:"nameRef,omitempty"`
+
+	// NameSelector selects a Stream to set Name and, if the Stream is in another domain, Domain of the source.
+	// +kubebuilder:validation:Optional
+	NameSelector *xpv1.Selector `json:"nameSelector,omitempty"`
 
 	// StartSeq is an optional start sequence the of the origin stream to start mirroring from.
 	// +kubebuilder:validation:Optional
@@ -220,8 +237,10 @@ type ExternalStream struct {
 type StreamObservationState struct {
 	// Mesasges is the number of messages in the stream.
 	Messages uint64 `json:"messages"`
-	// Bytes is the number of bytes in the stream.
+	// Bytes is the human-readable number of bytes in the stream.
 	Bytes string `json:"bytes"`
+	// NumBytes is the number of bytes in the stream.
+	NumBytes uint64 `json:"numBytes,omitempty"`
 	// FirstSequence is the first sequence number in the stream.
 	FirstSequence uint64 `json:"firstSequence"`
 	// FirstTimestamp is the first timestamp in the stream.
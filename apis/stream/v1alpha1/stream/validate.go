@@ -0,0 +1,46 @@
+package stream
+
+import (
+	"math"
+
+	"github.com/nats-io/nats.go"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns the errors of a stream configuration that the NATS server
+// would reject. It checks that the configuration converts to a NATS stream
+// configuration and applies the cross-field rules of JetStream.
+func Validate(config *StreamConfig, path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	errs = append(errs, config.MaxBytes.Validate(path.Child("maxBytes"), math.MaxInt64)...)
+	errs = append(errs, config.MaxMsgSize.Validate(path.Child("maxMsgSize"), math.MaxInt32)...)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	converted, err := ConfigV1Alpha1ToNats("", config)
+	if err != nil {
+		return append(errs, field.Invalid(path, field.OmitValueType{}, err.Error()))
+	}
+
+	if converted.Mirror != nil {
+		if len(converted.Subjects) > 0 {
+			errs = append(errs, field.Forbidden(path.Child("subjects"), "must not be set for a mirror"))
+		}
+		if len(converted.Sources) > 0 {
+			errs = append(errs, field.Forbidden(path.Child("sources"), "must not be set for a mirror"))
+		}
+	}
+
+	if converted.DiscardNewPerSubject {
+		if converted.Discard != nats.DiscardNew {
+			errs = append(errs, field.Invalid(path.Child("discardNewPerSubject"), converted.DiscardNewPerSubject, "requires discard New"))
+		}
+		if converted.MaxMsgsPerSubject <= 0 {
+			errs = append(errs, field.Invalid(path.Child("discardNewPerSubject"), converted.DiscardNewPerSubject, "requires maxMsgsPerSubject to be set"))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,70 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config StreamConfig
+		fields []string
+	}{
+		{
+			name:   "valid",
+			config: StreamConfig{Subjects: []string{"foo"}, MaxAge: "1h", Discard: "Old"},
+		},
+		{
+			name:   "unparsable MaxAge",
+			config: StreamConfig{MaxAge: "one hour"},
+			fields: []string{"config"},
+		},
+		{
+			name:   "byte quantities",
+			config: StreamConfig{MaxBytes: "1Gi", MaxMsgSize: "1Mi"},
+		},
+		{
+			name:   "unparsable MaxBytes",
+			config: StreamConfig{MaxBytes: "lots"},
+			fields: []string{"config.maxBytes"},
+		},
+		{
+			name:   "MaxBytes below -1",
+			config: StreamConfig{MaxBytes: "-2"},
+			fields: []string{"config.maxBytes"},
+		},
+		{
+			name:   "MaxMsgSize exceeding int32",
+			config: StreamConfig{MaxMsgSize: "2Gi"},
+			fields: []string{"config.maxMsgSize"},
+		},
+		{
+			name:   "mirror with subjects and sources",
+			config: StreamConfig{Subjects: []string{"foo"}, Mirror: &StreamSource{Name: "origin"}, Sources: []*StreamSource{{Name: "other"}}},
+			fields: []string{"config.subjects", "config.sources"},
+		},
+		{
+			name:   "DiscardNewPerSubject without Discard New",
+			config: StreamConfig{Discard: "Old", DiscardNewPerSubject: true, MaxMsgsPerSubject: 1},
+			fields: []string{"config.discardNewPerSubject"},
+		},
+		{
+			name:   "DiscardNewPerSubject without MaxMsgsPerSubject",
+			config: StreamConfig{Discard: "New", DiscardNewPerSubject: true, MaxMsgsPerSubject: -1},
+			fields: []string{"config.discardNewPerSubject"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(&tt.config, field.NewPath("config"))
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
@@ -21,7 +21,9 @@ limitations under the License.
 
 package stream
 
-import ()
+import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalStream) DeepCopyInto(out *ExternalStream) {
@@ -205,6 +207,16 @@ func (in *StreamObservationState) DeepCopy() *StreamObservationState {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StreamSource) DeepCopyInto(out *StreamSource) {
 	*out = *in
+	if in.NameRef != nil {
+		in, out := &in.NameRef, &out.NameRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NameSelector != nil {
+		in, out := &in.NameSelector, &out.NameSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.External != nil {
 		in, out := &in.External, &out.External
 		*out = new(ExternalStream)
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-nats-crossplane-io-v1alpha1-stream,mutating=false,failurePolicy=fail,groups=nats.crossplane.io,resources=streams,versions=v1alpha1,name=streams.nats.crossplane.io,sideEffects=None,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Stream{}
+
+// SetupWebhookWithManager registers the validating webhook of Streams and the
+// conversion webhook between their versions.
+func (mg *Stream) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(mg).Complete()
+}
+
+// ValidateCreate rejects Streams whose configuration the NATS server would reject.
+func (mg *Stream) ValidateCreate() error {
+	return mg.validate()
+}
+
+// ValidateUpdate rejects changes to the domain or the API prefix of a Stream,
+// as the stream would be managed through another JetStream API and the existing
+// stream would be orphaned. It also rejects changes to the configuration, the
+// purge request or the backup of a Stream that the NATS server would reject.
+// Updates that leave all of them unchanged are admitted, so that Streams created
+// before the webhook existed can still be reconciled and deleted.
+func (mg *Stream) ValidateUpdate(old runtime.Object) error {
+	o, ok := old.(*Stream)
+	if !ok {
+		return mg.validate()
+	}
+	p, path := mg.Spec.ForProvider, field.NewPath("spec", "forProvider")
+	errs := apivalidation.ValidateImmutableField(p.Domain, o.Spec.ForProvider.Domain, path.Child("domain"))
+	errs = append(errs, apivalidation.ValidateImmutableField(p.APIPrefix, o.Spec.ForProvider.APIPrefix, path.Child("apiPrefix"))...)
+	if len(errs) > 0 {
+		return kerrors.NewInvalid(StreamGroupVersionKind.GroupKind(), mg.GetName(), errs)
+	}
+	if reflect.DeepEqual(o.Spec.ForProvider.Config, mg.Spec.ForProvider.Config) &&
+		reflect.DeepEqual(o.Spec.ForProvider.Purge, mg.Spec.ForProvider.Purge) &&
+		o.Spec.ForProvider.UpdatePolicy == mg.Spec.ForProvider.UpdatePolicy &&
+		reflect.DeepEqual(o.Spec.ForProvider.Backup, mg.Spec.ForProvider.Backup) {
+		return nil
+	}
+	return mg.validate()
+}
+
+// ValidateDelete admits all deletions.
+func (mg *Stream) ValidateDelete() error {
+	return nil
+}
+
+func (mg *Stream) validate() error {
+	errs := stream.Validate(&mg.Spec.ForProvider.Config, field.NewPath("spec", "forProvider", "config"))
+	errs = append(errs, mg.Spec.ForProvider.Purge.Validate(field.NewPath("spec", "forProvider", "purge"))...)
+	errs = append(errs, validateBackup(&mg.Spec.ForProvider, field.NewPath("spec", "forProvider", "backup"))...)
+	if mg.Spec.ForProvider.Domain != "" && mg.Spec.ForProvider.APIPrefix != "" {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "forProvider", "apiPrefix"), "must not be set together with domain"))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return kerrors.NewInvalid(StreamGroupVersionKind.GroupKind(), mg.GetName(), errs)
+}
+
+// validateBackup requires a valid backup target for the update policy
+// RecreateWithBackup, as the snapshot would be lost otherwise.
+func validateBackup(p *StreamParameters, path *field.Path) field.ErrorList {
+	switch {
+	case p.Backup != nil:
+		if err := p.Backup.Validate(); err != nil {
+			return field.ErrorList{field.Invalid(path, p.Backup, err.Error())}
+		}
+	case p.UpdatePolicy == stream.UpdatePolicyRecreateWithBackup:
+		return field.ErrorList{field.Required(path, "must be set for update policy RecreateWithBackup")}
+	}
+	return nil
+}
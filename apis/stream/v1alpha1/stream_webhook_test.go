@@ -0,0 +1,47 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestValidateUpdateLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		old     StreamParameters
+		new     StreamParameters
+		invalid bool
+	}{
+		{
+			name: "unchanged",
+			old:  StreamParameters{APIPrefix: "$JS.hub.API"},
+			new:  StreamParameters{APIPrefix: "$JS.hub.API"},
+		},
+		{
+			name:    "API prefix changed",
+			old:     StreamParameters{APIPrefix: "$JS.hub.API"},
+			new:     StreamParameters{APIPrefix: "$JS.leaf.API"},
+			invalid: true,
+		},
+		{
+			name:    "API prefix set",
+			old:     StreamParameters{},
+			new:     StreamParameters{APIPrefix: "$JS.hub.API"},
+			invalid: true,
+		},
+		{
+			name:    "domain changed",
+			old:     StreamParameters{Domain: "hub"},
+			new:     StreamParameters{Domain: "leaf"},
+			invalid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Stream{Spec: StreamSpec{ForProvider: tt.new}}).ValidateUpdate(&Stream{Spec: StreamSpec{ForProvider: tt.old}})
+			assert.Equal(t, tt.invalid, kerrors.IsInvalid(err), err)
+		})
+	}
+}
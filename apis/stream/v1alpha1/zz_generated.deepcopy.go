@@ -22,7 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -90,6 +91,11 @@ func (in *StreamObservation) DeepCopyInto(out *StreamObservation) {
 	in.State.DeepCopyInto(&out.State)
 	in.ClusterInfo.DeepCopyInto(&out.ClusterInfo)
 	out.Connection = in.Connection
+	if in.LastPurge != nil {
+		in, out := &in.LastPurge, &out.LastPurge
+		*out = new(apisv1alpha1.StreamPurgeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamObservation.
@@ -105,6 +111,21 @@ func (in *StreamObservation) DeepCopy() *StreamObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StreamParameters) DeepCopyInto(out *StreamParameters) {
 	*out = *in
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(apisv1alpha1.BackupTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(apisv1alpha1.StreamHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Purge != nil {
+		in, out := &in.Purge, &out.Purge
+		*out = new(apisv1alpha1.StreamPurge)
+		**out = **in
+	}
 	in.Config.DeepCopyInto(&out.Config)
 }
 
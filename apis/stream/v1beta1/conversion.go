@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+	"github.com/edgefarm/provider-nats/apis/stream/v1beta1/stream"
+)
+
+var _ conversion.Convertible = &Stream{}
+
+// ConvertTo converts this Stream to the hub version v1alpha1.
+func (mg *Stream) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*v1alpha1.Stream)
+	config, err := stream.ConfigToV1Alpha1(&mg.Spec.ForProvider.Config)
+	if err != nil {
+		return err
+	}
+	state, err := stream.StateToV1Alpha1(&mg.Status.AtProvider.State)
+	if err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = mg.ObjectMeta
+	dst.Spec = v1alpha1.StreamSpec{
+		ResourceSpec:     mg.Spec.ResourceSpec,
+		ManagementPolicy: mg.Spec.ManagementPolicy,
+		ForProvider: v1alpha1.StreamParameters{
+			Domain:       mg.Spec.ForProvider.Domain,
+			APIPrefix:    mg.Spec.ForProvider.APIPrefix,
+			UpdatePolicy: mg.Spec.ForProvider.UpdatePolicy,
+			Backup:       mg.Spec.ForProvider.Backup,
+			Health:       mg.Spec.ForProvider.Health,
+			Purge:        mg.Spec.ForProvider.Purge,
+			Config:       *config,
+		},
+	}
+	dst.Status = v1alpha1.StreamStatus{
+		ResourceStatus: mg.Status.ResourceStatus,
+		AtProvider: v1alpha1.StreamObservation{
+			Domain:      mg.Status.AtProvider.Domain,
+			LastPurge:   mg.Status.AtProvider.LastPurge,
+			State:       *state,
+			ClusterInfo: *stream.ClusterInfoToV1Alpha1(&mg.Status.AtProvider.ClusterInfo),
+			Connection: streamv1alpha1.StreamObservationConnection{
+				Address:          mg.Status.AtProvider.Connection.Address,
+				AccountPublicKey: mg.Status.AtProvider.Connection.AccountPublicKey,
+				UserPublicKey:    mg.Status.AtProvider.Connection.UserPublicKey,
+			},
+		},
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version v1alpha1 to this Stream.
+func (mg *Stream) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*v1alpha1.Stream)
+	config, err := stream.ConfigFromV1Alpha1(&src.Spec.ForProvider.Config)
+	if err != nil {
+		return err
+	}
+	state, err := stream.StateFromV1Alpha1(&src.Status.AtProvider.State)
+	if err != nil {
+		return err
+	}
+	clusterInfo, err := stream.ClusterInfoFromV1Alpha1(&src.Status.AtProvider.ClusterInfo)
+	if err != nil {
+		return err
+	}
+
+	mg.ObjectMeta = src.ObjectMeta
+	mg.Spec = StreamSpec{
+		ResourceSpec:     src.Spec.ResourceSpec,
+		ManagementPolicy: src.Spec.ManagementPolicy,
+		ForProvider: StreamParameters{
+			Domain:       src.Spec.ForProvider.Domain,
+			APIPrefix:    src.Spec.ForProvider.APIPrefix,
+			UpdatePolicy: src.Spec.ForProvider.UpdatePolicy,
+			Backup:       src.Spec.ForProvider.Backup,
+			Health:       src.Spec.ForProvider.Health,
+			Purge:        src.Spec.ForProvider.Purge,
+			Config:       *config,
+		},
+	}
+	mg.Status = StreamStatus{
+		ResourceStatus: src.Status.ResourceStatus,
+		AtProvider: StreamObservation{
+			Domain:      src.Status.AtProvider.Domain,
+			LastPurge:   src.Status.AtProvider.LastPurge,
+			State:       *state,
+			ClusterInfo: *clusterInfo,
+			Connection: stream.StreamObservationConnection{
+				Address:          src.Status.AtProvider.Connection.Address,
+				AccountPublicKey: src.Status.AtProvider.Connection.AccountPublicKey,
+				UserPublicKey:    src.Status.AtProvider.Connection.UserPublicKey,
+			},
+		},
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package stream
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+	convert "github.com/edgefarm/provider-nats/internal/convert"
+)
+
+// ConfigToV1Alpha1 converts a v1beta1 stream configuration to v1alpha1.
+func ConfigToV1Alpha1(in *StreamConfig) (*stream.StreamConfig, error) {
+	out := &stream.StreamConfig{
+		Description:          in.Description,
+		Subjects:             in.Subjects,
+		Retention:            in.Retention,
+		MaxConsumers:         in.MaxConsumers,
+		MaxMsgs:              in.MaxMsgs,
+		MaxBytes:             in.MaxBytes,
+		Discard:              in.Discard,
+		DiscardNewPerSubject: in.DiscardNewPerSubject,
+		MaxAge:               convert.DurationToString(in.MaxAge),
+		MaxMsgsPerSubject:    in.MaxMsgsPerSubject,
+		MaxMsgSize:           in.MaxMsgSize,
+		Storage:              in.Storage,
+		Replicas:             in.Replicas,
+		NoAck:                in.NoAck,
+		TemplateOwner:        in.TemplateOwner,
+		Duplicates:           convert.DurationToString(in.Duplicates),
+		Sealed:               in.Sealed,
+		DenyDelete:           in.DenyDelete,
+		DenyPurge:            in.DenyPurge,
+		AllowRollup:          in.AllowRollup,
+		AllowDirect:          in.AllowDirect,
+		MirrorDirect:         in.MirrorDirect,
+	}
+	if in.Placement != nil {
+		out.Placement = &stream.Placement{
+			Cluster: in.Placement.Cluster,
+			Tags:    in.Placement.Tags,
+		}
+	}
+	if in.RePublish != nil {
+		out.RePublish = &stream.RePublish{
+			Source:      in.RePublish.Source,
+			Destination: in.RePublish.Destination,
+			HeadersOnly: in.RePublish.HeadersOnly,
+		}
+	}
+	if in.Mirror != nil {
+		mirror, err := sourceToV1Alpha1(in.Mirror)
+		if err != nil {
+			return nil, fmt.Errorf("mirror: %w", err)
+		}
+		out.Mirror = mirror
+	}
+	for i := range in.Sources {
+		source, err := sourceToV1Alpha1(&in.Sources[i])
+		if err != nil {
+			return nil, fmt.Errorf("sources[%d]: %w", i, err)
+		}
+		out.Sources = append(out.Sources, source)
+	}
+	return out, nil
+}
+
+// ConfigFromV1Alpha1 converts a v1alpha1 stream configuration to v1beta1.
+func ConfigFromV1Alpha1(in *stream.StreamConfig) (*StreamConfig, error) {
+	maxAge, err := convert.StringToDuration(in.MaxAge)
+	if err != nil {
+		return nil, fmt.Errorf("maxAge: %w", err)
+	}
+	duplicates, err := convert.StringToDuration(in.Duplicates)
+	if err != nil {
+		return nil, fmt.Errorf("duplicates: %w", err)
+	}
+	out := &StreamConfig{
+		Description:          in.Description,
+		Subjects:             in.Subjects,
+		Retention:            in.Retention,
+		MaxConsumers:         in.MaxConsumers,
+		MaxMsgs:              in.MaxMsgs,
+		MaxBytes:             in.MaxBytes,
+		Discard:              in.Discard,
+		DiscardNewPerSubject: in.DiscardNewPerSubject,
+		MaxAge:               maxAge,
+		MaxMsgsPerSubject:    in.MaxMsgsPerSubject,
+		MaxMsgSize:           in.MaxMsgSize,
+		Storage:              in.Storage,
+		Replicas:             in.Replicas,
+		NoAck:                in.NoAck,
+		TemplateOwner:        in.TemplateOwner,
+		Duplicates:           duplicates,
+		Sealed:               in.Sealed,
+		DenyDelete:           in.DenyDelete,
+		DenyPurge:            in.DenyPurge,
+		AllowRollup:          in.AllowRollup,
+		AllowDirect:          in.AllowDirect,
+		MirrorDirect:         in.MirrorDirect,
+	}
+	if in.Placement != nil {
+		out.Placement = &Placement{
+			Cluster: in.Placement.Cluster,
+			Tags:    in.Placement.Tags,
+		}
+	}
+	if in.RePublish != nil {
+		out.RePublish = &RePublish{
+			Source:      in.RePublish.Source,
+			Destination: in.RePublish.Destination,
+			HeadersOnly: in.RePublish.HeadersOnly,
+		}
+	}
+	if in.Mirror != nil {
+		mirror, err := sourceFromV1Alpha1(in.Mirror)
+		if err != nil {
+			return nil, fmt.Errorf("mirror: %w", err)
+		}
+		out.Mirror = mirror
+	}
+	for i, s := range in.Sources {
+		if s == nil {
+			continue
+		}
+		source, err := sourceFromV1Alpha1(s)
+		if err != nil {
+			return nil, fmt.Errorf("sources[%d]: %w", i, err)
+		}
+		out.Sources = append(out.Sources, *source)
+	}
+	return out, nil
+}
+
+func sourceToV1Alpha1(in *StreamSource) (*stream.StreamSource, error) {
+	startTime, err := convert.MetaTimeToRFC3339(in.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("startTime: %w", err)
+	}
+	out := &stream.StreamSource{
+		Name:          in.Name,
+		NameRef:       in.NameRef,
+		NameSelector:  in.NameSelector,
+		StartSeq:      in.StartSeq,
+		StartTime:     startTime,
+		FilterSubject: in.FilterSubject,
+		Domain:        in.Domain,
+	}
+	if in.External != nil {
+		out.External = &stream.ExternalStream{
+			APIPrefix:     in.External.APIPrefix,
+			DeliverPrefix: in.External.DeliverPrefix,
+		}
+	}
+	return out, nil
+}
+
+func sourceFromV1Alpha1(in *stream.StreamSource) (*StreamSource, error) {
+	startTime, err := convert.RFC3339ToMetaTime(in.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("startTime: %w", err)
+	}
+	out := &StreamSource{
+		Name:          in.Name,
+		NameRef:       in.NameRef,
+		NameSelector:  in.NameSelector,
+		StartSeq:      in.StartSeq,
+		StartTime:     startTime,
+		FilterSubject: in.FilterSubject,
+		Domain:        in.Domain,
+	}
+	if in.External != nil {
+		out.External = &ExternalStream{
+			APIPrefix:     in.External.APIPrefix,
+			DeliverPrefix: in.External.DeliverPrefix,
+		}
+	}
+	return out, nil
+}
+
+// StateToV1Alpha1 converts a v1beta1 stream state to v1alpha1.
+func StateToV1Alpha1(in *StreamObservationState) (*stream.StreamObservationState, error) {
+	firstTimestamp, err := convert.MetaTimeToRFC3339(in.FirstTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	lastTimestamp, err := convert.MetaTimeToRFC3339(in.LastTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	return &stream.StreamObservationState{
+		Messages:       in.Messages,
+		Bytes:          humanize.Bytes(in.Bytes),
+		NumBytes:       in.Bytes,
+		FirstSequence:  in.FirstSequence,
+		FirstTimestamp: firstTimestamp,
+		LastSequence:   in.LastSequence,
+		LastTimestamp:  lastTimestamp,
+		ConsumerCount:  in.ConsumerCount,
+		Deleted:        in.Deleted,
+		NumDeleted:     in.NumDeleted,
+		NumSubjects:    in.NumSubjects,
+		Subjects:       in.Subjects,
+	}, nil
+}
+
+// StateFromV1Alpha1 converts a v1alpha1 stream state to v1beta1. The human
+// readable byte count of v1alpha1 is used if the numeric one is not set.
+func StateFromV1Alpha1(in *stream.StreamObservationState) (*StreamObservationState, error) {
+	firstTimestamp, err := convert.RFC3339ToMetaTime(in.FirstTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	lastTimestamp, err := convert.RFC3339ToMetaTime(in.LastTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	bytes := in.NumBytes
+	if bytes == 0 && in.Bytes != "" {
+		if b, err := humanize.ParseBytes(in.Bytes); err == nil {
+			bytes = b
+		}
+	}
+	return &StreamObservationState{
+		Messages:       in.Messages,
+		Bytes:          bytes,
+		FirstSequence:  in.FirstSequence,
+		FirstTimestamp: firstTimestamp,
+		LastSequence:   in.LastSequence,
+		LastTimestamp:  lastTimestamp,
+		ConsumerCount:  in.ConsumerCount,
+		Deleted:        in.Deleted,
+		NumDeleted:     in.NumDeleted,
+		NumSubjects:    in.NumSubjects,
+		Subjects:       in.Subjects,
+	}, nil
+}
+
+// ClusterInfoToV1Alpha1 converts v1beta1 cluster information to v1alpha1.
+func ClusterInfoToV1Alpha1(in *StreamObservationClusterInfo) *stream.StreamObservationClusterInfo {
+	out := &stream.StreamObservationClusterInfo{
+		Name:   in.Name,
+		Leader: in.Leader,
+	}
+	for _, peer := range in.Replicas {
+		out.Replicas = append(out.Replicas, &stream.PeerInfo{
+			Name:    peer.Name,
+			Current: peer.Current,
+			Offline: peer.Offline,
+			Active:  peer.Active.Duration.String(),
+			Lag:     peer.Lag,
+		})
+	}
+	return out
+}
+
+// ClusterInfoFromV1Alpha1 converts v1alpha1 cluster information to v1beta1.
+func ClusterInfoFromV1Alpha1(in *stream.StreamObservationClusterInfo) (*StreamObservationClusterInfo, error) {
+	out := &StreamObservationClusterInfo{
+		Name:   in.Name,
+		Leader: in.Leader,
+	}
+	for _, peer := range in.Replicas {
+		if peer == nil {
+			continue
+		}
+		var active time.Duration
+		if peer.Active != "" {
+			var err error
+			if active, err = time.ParseDuration(peer.Active); err != nil {
+				return nil, err
+			}
+		}
+		out.Replicas = append(out.Replicas, PeerInfo{
+			Name:    peer.Name,
+			Current: peer.Current,
+			Offline: peer.Offline,
+			Active:  metav1.Duration{Duration: active},
+			Lag:     peer.Lag,
+		})
+	}
+	return out, nil
+}
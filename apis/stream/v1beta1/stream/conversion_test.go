@@ -0,0 +1,99 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+func TestConfigConversion(t *testing.T) {
+	assert := assert.New(t)
+	startTime := metav1.NewTime(time.Date(2023, 1, 9, 14, 48, 32, 0, time.UTC))
+
+	in := &StreamConfig{
+		Subjects:   []string{"foo.>"},
+		Retention:  "Limits",
+		MaxBytes:   "1Gi",
+		Discard:    "Old",
+		MaxAge:     &metav1.Duration{Duration: 90 * time.Minute},
+		MaxMsgSize: "-1",
+		Storage:    "File",
+		Replicas:   1,
+		Placement:  &Placement{Cluster: "c1", Tags: []string{"a"}},
+		Sources: []StreamSource{
+			{Name: "origin", StartTime: &startTime, Domain: "leaf"},
+			{Name: "other", External: &ExternalStream{APIPrefix: "$JS.hub.API"}},
+		},
+		RePublish: &RePublish{Source: ">", Destination: "re.>"},
+	}
+
+	alpha, err := ConfigToV1Alpha1(in)
+	assert.Nil(err)
+	assert.Equal("1h30m0s", alpha.MaxAge)
+	assert.Equal("", alpha.Duplicates)
+	assert.Len(alpha.Sources, 2)
+	assert.Equal("2023-01-09T14:48:32Z", alpha.Sources[0].StartTime)
+	assert.Equal("$JS.hub.API", alpha.Sources[1].External.APIPrefix)
+
+	out, err := ConfigFromV1Alpha1(alpha)
+	assert.Nil(err)
+	assert.True(out.Sources[0].StartTime.Equal(&startTime))
+	out.Sources[0].StartTime = in.Sources[0].StartTime
+	assert.Equal(in, out)
+}
+
+func TestConfigFromV1Alpha1Invalid(t *testing.T) {
+	_, err := ConfigFromV1Alpha1(&stream.StreamConfig{MaxAge: "forever"})
+	assert.ErrorContains(t, err, "maxAge")
+
+	_, err = ConfigFromV1Alpha1(&stream.StreamConfig{Mirror: &stream.StreamSource{StartTime: "yesterday"}})
+	assert.ErrorContains(t, err, "mirror")
+}
+
+func TestStateConversion(t *testing.T) {
+	assert := assert.New(t)
+	first := metav1.NewTime(time.Date(2023, 1, 9, 14, 48, 32, 0, time.UTC))
+
+	in := &StreamObservationState{
+		Messages:       10,
+		Bytes:          2048,
+		FirstSequence:  1,
+		FirstTimestamp: &first,
+		LastSequence:   10,
+		ConsumerCount:  2,
+	}
+	alpha, err := StateToV1Alpha1(in)
+	assert.Nil(err)
+	assert.Equal("2.0 kB", alpha.Bytes)
+	assert.Equal(uint64(2048), alpha.NumBytes)
+
+	out, err := StateFromV1Alpha1(alpha)
+	assert.Nil(err)
+	assert.Equal(in, out)
+
+	// Objects observed before numBytes was introduced only have the human readable size.
+	out, err = StateFromV1Alpha1(&stream.StreamObservationState{Bytes: "2.0 kB"})
+	assert.Nil(err)
+	assert.Equal(uint64(2000), out.Bytes)
+}
+
+func TestClusterInfoConversion(t *testing.T) {
+	assert := assert.New(t)
+	in := &StreamObservationClusterInfo{
+		Name:   "c1",
+		Leader: "n1",
+		Replicas: []PeerInfo{
+			{Name: "n2", Current: true, Active: metav1.Duration{Duration: 1500 * time.Millisecond}, Lag: 3},
+		},
+	}
+	alpha := ClusterInfoToV1Alpha1(in)
+	assert.Equal("1.5s", alpha.Replicas[0].Active)
+
+	out, err := ClusterInfoFromV1Alpha1(alpha)
+	assert.Nil(err)
+	assert.Equal(in, out)
+}
@@ -0,0 +1,249 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package stream
+
+import (
+	commonv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalStream) DeepCopyInto(out *ExternalStream) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalStream.
+func (in *ExternalStream) DeepCopy() *ExternalStream {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerInfo) DeepCopyInto(out *PeerInfo) {
+	*out = *in
+	out.Active = in.Active
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerInfo.
+func (in *PeerInfo) DeepCopy() *PeerInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Placement) DeepCopyInto(out *Placement) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Placement.
+func (in *Placement) DeepCopy() *Placement {
+	if in == nil {
+		return nil
+	}
+	out := new(Placement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RePublish) DeepCopyInto(out *RePublish) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RePublish.
+func (in *RePublish) DeepCopy() *RePublish {
+	if in == nil {
+		return nil
+	}
+	out := new(RePublish)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamConfig) DeepCopyInto(out *StreamConfig) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Duplicates != nil {
+		in, out := &in.Duplicates, &out.Duplicates
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(Placement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(StreamSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]StreamSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RePublish != nil {
+		in, out := &in.RePublish, &out.RePublish
+		*out = new(RePublish)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamConfig.
+func (in *StreamConfig) DeepCopy() *StreamConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamObservationClusterInfo) DeepCopyInto(out *StreamObservationClusterInfo) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]PeerInfo, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamObservationClusterInfo.
+func (in *StreamObservationClusterInfo) DeepCopy() *StreamObservationClusterInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamObservationClusterInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamObservationConnection) DeepCopyInto(out *StreamObservationConnection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamObservationConnection.
+func (in *StreamObservationConnection) DeepCopy() *StreamObservationConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamObservationConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamObservationState) DeepCopyInto(out *StreamObservationState) {
+	*out = *in
+	if in.FirstTimestamp != nil {
+		in, out := &in.FirstTimestamp, &out.FirstTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTimestamp != nil {
+		in, out := &in.LastTimestamp, &out.LastTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Deleted != nil {
+		in, out := &in.Deleted, &out.Deleted
+		*out = make([]uint64, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make(map[string]uint64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamObservationState.
+func (in *StreamObservationState) DeepCopy() *StreamObservationState {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamObservationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamSource) DeepCopyInto(out *StreamSource) {
+	*out = *in
+	if in.NameRef != nil {
+		in, out := &in.NameRef, &out.NameRef
+		*out = new(commonv1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NameSelector != nil {
+		in, out := &in.NameSelector, &out.NameSelector
+		*out = new(commonv1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalStream)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamSource.
+func (in *StreamSource) DeepCopy() *StreamSource {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamSource)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+const (
+	errNoTarget        = "either path or s3 must be set for the backup target"
+	errAmbiguousTarget = "only one of path or s3 may be set for the backup target"
+)
+
+// BackupTarget is the location stream snapshots are written to and read from.
+// Exactly one of Path or S3 must be set.
+type BackupTarget struct {
+	// Path is a directory on a volume mounted into the provider, e.g. a
+	// PersistentVolumeClaim. Snapshots are stored in <path>/<stream>/<backup>.
+	// +kubebuilder:validation:Optional
+	Path string `json:"path,omitempty"`
+
+	// S3 is a bucket of an S3-compatible object storage, e.g. MinIO.
+	// Snapshots are stored as objects below <prefix>/<stream>/<backup>.
+	// +kubebuilder:validation:Optional
+	S3 *S3Target `json:"s3,omitempty"`
+}
+
+// S3Target is a bucket of an S3-compatible object storage.
+type S3Target struct {
+	// Endpoint is the host and optional port of the object storage, e.g. minio.minio.svc:9000.
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the name of the bucket. It must already exist.
+	// +kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the keys of all snapshot objects.
+	// +kubebuilder:validation:Optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the region of the bucket.
+	// +kubebuilder:validation:Optional
+	Region string `json:"region,omitempty"`
+
+	// Insecure connects to the object storage using plain HTTP instead of HTTPS.
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// AccessKeyIDSecretRef references the access key ID used to authenticate.
+	// The object storage is accessed anonymously if it is not set.
+	// +kubebuilder:validation:Optional
+	AccessKeyIDSecretRef *xpv1.SecretKeySelector `json:"accessKeyIdSecretRef,omitempty"`
+
+	// SecretAccessKeySecretRef references the secret access key used to authenticate.
+	// +kubebuilder:validation:Optional
+	SecretAccessKeySecretRef *xpv1.SecretKeySelector `json:"secretAccessKeySecretRef,omitempty"`
+}
+
+// Validate returns an error unless exactly one kind of target is set.
+func (t *BackupTarget) Validate() error {
+	switch {
+	case t.Path == "" && t.S3 == nil:
+		return errors.New(errNoTarget)
+	case t.Path != "" && t.S3 != nil:
+		return errors.New(errAmbiguousTarget)
+	}
+	return nil
+}
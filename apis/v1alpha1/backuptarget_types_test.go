@@ -0,0 +1,15 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupTargetValidate(t *testing.T) {
+	assert := assert.New(t)
+	assert.EqualError((&BackupTarget{}).Validate(), errNoTarget)
+	assert.EqualError((&BackupTarget{Path: "/backups", S3: &S3Target{}}).Validate(), errAmbiguousTarget)
+	assert.Nil((&BackupTarget{Path: "/backups"}).Validate())
+	assert.Nil((&BackupTarget{S3: &S3Target{Endpoint: "minio:9000", Bucket: "backups"}}).Validate())
+}
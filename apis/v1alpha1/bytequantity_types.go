@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// A ByteQuantity is an amount of bytes. It is either a plain integer or a
+// quantity with a Kubernetes suffix such as 512Mi or a humanized unit such as 1GB.
+// +kubebuilder:validation:XIntOrString
+// +kubebuilder:validation:Type=""
+type ByteQuantity string
+
+// NewByteQuantity returns the ByteQuantity of a plain amount of bytes.
+func NewByteQuantity(bytes int64) ByteQuantity {
+	return ByteQuantity(strconv.FormatInt(bytes, 10))
+}
+
+// Value returns the amount of bytes. An empty quantity is zero.
+func (q ByteQuantity) Value() (int64, error) {
+	s := string(q)
+	if s == "" {
+		return 0, nil
+	}
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return v, nil
+	}
+	if v, err := resource.ParseQuantity(s); err == nil {
+		return v.Value(), nil
+	}
+	v, err := humanize.ParseBytes(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte quantity %q", s)
+	}
+	if v > uint64(1<<63-1) {
+		return 0, fmt.Errorf("byte quantity %q is too large", s)
+	}
+	return int64(v), nil
+}
+
+// Validate returns the errors of a quantity that does not parse or is neither
+// -1, which means unlimited, nor between 0 and max.
+func (q ByteQuantity) Validate(path *field.Path, max int64) field.ErrorList {
+	errs := field.ErrorList{}
+	v, err := q.Value()
+	if err != nil {
+		return append(errs, field.Invalid(path, string(q), err.Error()))
+	}
+	if v < -1 {
+		errs = append(errs, field.Invalid(path, string(q), "must be -1 or greater"))
+	}
+	if v > max {
+		errs = append(errs, field.Invalid(path, string(q), fmt.Sprintf("must not exceed %d bytes", max)))
+	}
+	return errs
+}
+
+// UnmarshalJSON accepts both JSON numbers and strings.
+func (q *ByteQuantity) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*q = ByteQuantity(s)
+		return nil
+	}
+	var v int64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*q = NewByteQuantity(v)
+	return nil
+}
+
+// MarshalJSON returns plain integers as JSON numbers and all other quantities
+// as JSON strings.
+func (q ByteQuantity) MarshalJSON() ([]byte, error) {
+	if v, err := strconv.ParseInt(string(q), 10, 64); err == nil {
+		return json.Marshal(v)
+	}
+	return json.Marshal(string(q))
+}
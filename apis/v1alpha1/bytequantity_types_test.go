@@ -0,0 +1,73 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestByteQuantityValue(t *testing.T) {
+	tests := []struct {
+		quantity ByteQuantity
+		want     int64
+		err      bool
+	}{
+		{quantity: "", want: 0},
+		{quantity: "-1", want: -1},
+		{quantity: "1073741824", want: 1073741824},
+		{quantity: "512Mi", want: 512 * 1024 * 1024},
+		{quantity: "1G", want: 1000 * 1000 * 1000},
+		{quantity: "1GB", want: 1000 * 1000 * 1000},
+		{quantity: "1GiB", want: 1024 * 1024 * 1024},
+		{quantity: "1.5 MB", want: 1500 * 1000},
+		{quantity: "lots", err: true},
+	}
+	for _, tt := range tests {
+		got, err := tt.quantity.Value()
+		if tt.err {
+			assert.Error(t, err, tt.quantity)
+			continue
+		}
+		assert.Nil(t, err, tt.quantity)
+		assert.Equal(t, tt.want, got, tt.quantity)
+	}
+}
+
+func TestByteQuantityValidate(t *testing.T) {
+	tests := []struct {
+		quantity ByteQuantity
+		max      int64
+		errs     int
+	}{
+		{quantity: "", max: math.MaxInt32},
+		{quantity: "-1", max: math.MaxInt32},
+		{quantity: "1Mi", max: math.MaxInt32},
+		{quantity: "2Gi", max: math.MaxInt64},
+		{quantity: "2Gi", max: math.MaxInt32, errs: 1},
+		{quantity: "-2", max: math.MaxInt64, errs: 1},
+		{quantity: "-1Ki", max: math.MaxInt64, errs: 1},
+		{quantity: "lots", max: math.MaxInt64, errs: 1},
+	}
+	for _, tt := range tests {
+		errs := tt.quantity.Validate(field.NewPath("maxBytes"), tt.max)
+		assert.Len(t, errs, tt.errs, tt.quantity)
+	}
+}
+
+func TestByteQuantityJSON(t *testing.T) {
+	assert := assert.New(t)
+	var v struct {
+		Int    ByteQuantity `json:"int"`
+		String ByteQuantity `json:"string"`
+	}
+	assert.Nil(json.Unmarshal([]byte(`{"int":4294967296,"string":"512Mi"}`), &v))
+	assert.Equal(ByteQuantity("4294967296"), v.Int)
+	assert.Equal(ByteQuantity("512Mi"), v.String)
+
+	out, err := json.Marshal(v)
+	assert.Nil(err)
+	assert.JSONEq(`{"int":4294967296,"string":"512Mi"}`, string(out))
+}
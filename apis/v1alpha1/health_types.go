@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types of the health of streams and consumers.
+const (
+	// TypeReplicasHealthy indicates whether all replicas are online and
+	// caught up with the leader.
+	TypeReplicasHealthy xpv1.ConditionType = "ReplicasHealthy"
+
+	// TypeLeaderElected indicates whether the replicas elected a leader.
+	TypeLeaderElected xpv1.ConditionType = "LeaderElected"
+
+	// TypeBacklogWithinLimits indicates whether the backlog of a consumer is
+	// within the configured limits.
+	TypeBacklogWithinLimits xpv1.ConditionType = "BacklogWithinLimits"
+)
+
+// Reasons of the health conditions.
+const (
+	ReasonReplicasCurrent xpv1.ConditionReason = "ReplicasCurrent"
+	ReasonReplicaOffline  xpv1.ConditionReason = "ReplicaOffline"
+	ReasonReplicaLagging  xpv1.ConditionReason = "ReplicaLagging"
+	ReasonLeaderElected   xpv1.ConditionReason = "LeaderElected"
+	ReasonNoLeader        xpv1.ConditionReason = "NoLeader"
+	ReasonWithinLimits    xpv1.ConditionReason = "WithinLimits"
+	ReasonLimitExceeded   xpv1.ConditionReason = "LimitExceeded"
+)
+
+// StreamHealth configures when a stream is reported as unhealthy.
+type StreamHealth struct {
+	// MaxReplicaLag is the number of operations a replica may be behind the
+	// leader before the replicas are reported as unhealthy. Defaults to 1000.
+	// +kubebuilder:validation:Optional
+	MaxReplicaLag *uint64 `json:"maxReplicaLag,omitempty"`
+}
+
+// ConsumerHealth configures when a consumer is reported as unhealthy.
+type ConsumerHealth struct {
+	// MaxReplicaLag is the number of operations a replica may be behind the
+	// leader before the replicas are reported as unhealthy. Defaults to 1000.
+	// +kubebuilder:validation:Optional
+	MaxReplicaLag *uint64 `json:"maxReplicaLag,omitempty"`
+
+	// MaxPending is the number of messages of the stream the consumer may not
+	// have delivered yet before its backlog is reported as exceeded.
+	// +kubebuilder:validation:Optional
+	MaxPending *uint64 `json:"maxPending,omitempty"`
+
+	// MaxAckPending is the number of delivered messages that may wait for an
+	// acknowledgement before the backlog is reported as exceeded.
+	// +kubebuilder:validation:Optional
+	MaxAckPending *int `json:"maxAckPending,omitempty"`
+}
+
+// ReplicasHealthy returns a condition that indicates all replicas are online
+// and caught up with the leader.
+func ReplicasHealthy() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReplicasHealthy,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReplicasCurrent,
+	}
+}
+
+// ReplicasUnhealthy returns a condition that indicates a replica is offline or
+// lagging behind the leader.
+func ReplicasUnhealthy(reason xpv1.ConditionReason, message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReplicasHealthy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// LeaderElected returns a condition that indicates the replicas elected a
+// leader.
+func LeaderElected(leader string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeLeaderElected,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonLeaderElected,
+		Message:            "Leader is " + leader,
+	}
+}
+
+// NoLeader returns a condition that indicates the replicas have no leader.
+func NoLeader() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeLeaderElected,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoLeader,
+	}
+}
+
+// BacklogWithinLimits returns a condition that indicates the backlog of a
+// consumer is within the configured limits.
+func BacklogWithinLimits() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBacklogWithinLimits,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWithinLimits,
+	}
+}
+
+// BacklogExceeded returns a condition that indicates the backlog of a consumer
+// exceeds the configured limits.
+func BacklogExceeded(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBacklogWithinLimits,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonLimitExceeded,
+		Message:            message,
+	}
+}
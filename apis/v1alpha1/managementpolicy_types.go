@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// A ManagementPolicy determines which operations the provider performs on the
+// external resource of a managed resource.
+// +kubebuilder:validation:Enum=FullControl;ObserveOnly;OrphanOnDelete
+type ManagementPolicy string
+
+const (
+	// ManagementFullControl creates, updates and deletes the external resource.
+	ManagementFullControl ManagementPolicy = "FullControl"
+
+	// ManagementObserveOnly only observes the external resource and reports
+	// its state. The external resource is never created, updated or deleted.
+	ManagementObserveOnly ManagementPolicy = "ObserveOnly"
+
+	// ManagementOrphanOnDelete creates and updates the external resource but
+	// never deletes it.
+	ManagementOrphanOnDelete ManagementPolicy = "OrphanOnDelete"
+)
+
+// ShouldCreate returns true if the external resource may be created.
+func (p ManagementPolicy) ShouldCreate() bool {
+	return p != ManagementObserveOnly
+}
+
+// ShouldUpdate returns true if the external resource may be updated.
+func (p ManagementPolicy) ShouldUpdate() bool {
+	return p != ManagementObserveOnly
+}
+
+// ShouldDelete returns true if the external resource may be deleted. An empty
+// policy is treated as FullControl.
+func (p ManagementPolicy) ShouldDelete() bool {
+	return p == "" || p == ManagementFullControl
+}
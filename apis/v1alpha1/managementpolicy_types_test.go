@@ -0,0 +1,27 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagementPolicy(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		policy ManagementPolicy
+		create bool
+		update bool
+		delete bool
+	}{
+		{policy: "", create: true, update: true, delete: true},
+		{policy: ManagementFullControl, create: true, update: true, delete: true},
+		{policy: ManagementObserveOnly, create: false, update: false, delete: false},
+		{policy: ManagementOrphanOnDelete, create: true, update: true, delete: false},
+	}
+	for _, tt := range tests {
+		assert.Equal(tt.create, tt.policy.ShouldCreate(), tt.policy)
+		assert.Equal(tt.update, tt.policy.ShouldUpdate(), tt.policy)
+		assert.Equal(tt.delete, tt.policy.ShouldDelete(), tt.policy)
+	}
+}
@@ -29,6 +29,22 @@ import (
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// TLS configures the TLS connection to the NATS server.
+	// +optional
+	TLS *ProviderTLS `json:"tls,omitempty"`
+
+	// RequestTimeout is the timeout of a single request to the JetStream API,
+	// e.g. 10s. A request is also cancelled when the reconcile times out.
+	// +kubebuilder:default="5s"
+	// +optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+
+	// APIPrefix is the prefix of the JetStream API used for resources without
+	// a domain, e.g. to manage the JetStream of another account that is imported
+	// under this prefix. Defaults to $JS.API.
+	// +optional
+	APIPrefix string `json:"apiPrefix,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -37,9 +53,47 @@ type ProviderCredentials struct {
 	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem
 	Source xpv1.CredentialsSource `json:"source"`
 
+	// Auth is the authentication method used to connect to the NATS server.
+	// It defines which fields of the credentials are used:
+	// UserJWT uses jwt and seed_key, CredsFile uses creds, NKey uses seed_key,
+	// UserPassword uses user and password, Token uses token and None uses no credentials.
+	// The address of the NATS server is always read from the credentials.
+	// +kubebuilder:validation:Enum=UserJWT;CredsFile;NKey;UserPassword;Token;None
+	// +kubebuilder:default=UserJWT
+	// +optional
+	Auth string `json:"auth,omitempty"`
+
 	xpv1.CommonCredentialSelectors `json:",inline"`
 }
 
+// ProviderTLS configures the TLS connection to the NATS server.
+// Certificates and keys are read from Secrets.
+type ProviderTLS struct {
+	// CASecretRef references a Secret key containing the PEM encoded CA bundle
+	// used to verify the certificate of the NATS server.
+	// +optional
+	CASecretRef *xpv1.SecretKeySelector `json:"caSecretRef,omitempty"`
+
+	// ClientCertSecretRef references a Secret key containing the PEM encoded
+	// client certificate used for mutual TLS.
+	// +optional
+	ClientCertSecretRef *xpv1.SecretKeySelector `json:"clientCertSecretRef,omitempty"`
+
+	// ClientKeySecretRef references a Secret key containing the PEM encoded
+	// client key used for mutual TLS.
+	// +optional
+	ClientKeySecretRef *xpv1.SecretKeySelector `json:"clientKeySecretRef,omitempty"`
+
+	// ServerName overrides the server name used to verify the certificate of the NATS server.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the certificate of the NATS server.
+	// This should only be used for development.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
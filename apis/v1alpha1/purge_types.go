@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// StreamPurge requests to purge messages of a stream. Without options all
+// messages are purged.
+type StreamPurge struct {
+	// Generation identifies the purge request. The purge runs once for every
+	// new generation, increment it to purge again.
+	// +kubebuilder:validation:Minimum=1
+	Generation int64 `json:"generation"`
+
+	// Subject only purges messages that match the subject, which may contain wildcards.
+	// +kubebuilder:validation:Optional
+	Subject string `json:"subject,omitempty"`
+
+	// Keep is the number of newest messages to keep, per subject if Subject is set.
+	// +kubebuilder:validation:Optional
+	Keep uint64 `json:"keep,omitempty"`
+
+	// Sequence purges all messages up to but not including this sequence.
+	// +kubebuilder:validation:Optional
+	Sequence uint64 `json:"sequence,omitempty"`
+}
+
+// StreamPurgeStatus is the result of the last purge of a stream.
+type StreamPurgeStatus struct {
+	// Generation is the generation of the purge request.
+	Generation int64 `json:"generation"`
+
+	// Time is the time the purge ran.
+	Time metav1.Time `json:"time"`
+
+	// Purged is the number of purged messages.
+	Purged uint64 `json:"purged"`
+
+	// Error is the error of the purge if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Validate returns the errors of a purge request the NATS server would reject.
+func (p *StreamPurge) Validate(path *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if p == nil {
+		return errs
+	}
+	if p.Keep > 0 && p.Sequence > 0 {
+		errs = append(errs, field.Forbidden(path.Child("sequence"), "keep and sequence cannot be used together"))
+	}
+	return errs
+}
+
+// Pending returns true if the purge request was not run yet.
+func (p *StreamPurge) Pending(last *StreamPurgeStatus) bool {
+	return p != nil && (last == nil || last.Generation != p.Generation)
+}
@@ -0,0 +1,28 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestStreamPurgeValidate(t *testing.T) {
+	path := field.NewPath("purge")
+	var p *StreamPurge
+	assert.Empty(t, p.Validate(path))
+	assert.Empty(t, (&StreamPurge{Generation: 1, Subject: "foo.>", Keep: 10}).Validate(path))
+	errs := (&StreamPurge{Generation: 1, Keep: 10, Sequence: 100}).Validate(path)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "purge.sequence", errs[0].Field)
+}
+
+func TestStreamPurgePending(t *testing.T) {
+	var p *StreamPurge
+	assert.False(t, p.Pending(nil))
+
+	p = &StreamPurge{Generation: 2}
+	assert.True(t, p.Pending(nil))
+	assert.True(t, p.Pending(&StreamPurgeStatus{Generation: 1}))
+	assert.False(t, p.Pending(&StreamPurgeStatus{Generation: 2}))
+}
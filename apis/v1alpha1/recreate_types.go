@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeRecreated indicates whether the last recreation of a stream to change
+// immutable fields succeeded.
+const TypeRecreated xpv1.ConditionType = "Recreated"
+
+// Reasons of the recreate condition.
+const (
+	ReasonRecreated          xpv1.ConditionReason = "Recreated"
+	ReasonRestoredFromBackup xpv1.ConditionReason = "RestoredFromBackup"
+	ReasonRecreateFailed     xpv1.ConditionReason = "RecreateFailed"
+)
+
+// Recreated returns a condition that indicates the stream was recreated.
+func Recreated() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRecreated,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRecreated,
+	}
+}
+
+// RestoredFromBackup returns a condition that indicates the stream could not
+// be created again after it was deleted and was restored from its snapshot.
+func RestoredFromBackup(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRecreated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRestoredFromBackup,
+		Message:            message,
+	}
+}
+
+// RecreateFailed returns a condition that indicates the stream was deleted
+// but could neither be created again nor restored.
+func RecreateFailed(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRecreated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRecreateFailed,
+		Message:            message,
+	}
+}
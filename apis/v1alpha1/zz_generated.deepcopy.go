@@ -22,9 +22,61 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTarget) DeepCopyInto(out *BackupTarget) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3Target)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTarget.
+func (in *BackupTarget) DeepCopy() *BackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerHealth) DeepCopyInto(out *ConsumerHealth) {
+	*out = *in
+	if in.MaxReplicaLag != nil {
+		in, out := &in.MaxReplicaLag, &out.MaxReplicaLag
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.MaxPending != nil {
+		in, out := &in.MaxPending, &out.MaxPending
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.MaxAckPending != nil {
+		in, out := &in.MaxAckPending, &out.MaxAckPending
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerHealth.
+func (in *ConsumerHealth) DeepCopy() *ConsumerHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	*out = *in
@@ -88,6 +140,16 @@ func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
 func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
 	*out = *in
 	in.Credentials.DeepCopyInto(&out.Credentials)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ProviderTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestTimeout != nil {
+		in, out := &in.RequestTimeout, &out.RequestTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
@@ -190,6 +252,61 @@ func (in *ProviderCredentials) DeepCopy() *ProviderCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderTLS) DeepCopyInto(out *ProviderTLS) {
+	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ClientKeySecretRef != nil {
+		in, out := &in.ClientKeySecretRef, &out.ClientKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTLS.
+func (in *ProviderTLS) DeepCopy() *ProviderTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Target) DeepCopyInto(out *S3Target) {
+	*out = *in
+	if in.AccessKeyIDSecretRef != nil {
+		in, out := &in.AccessKeyIDSecretRef, &out.AccessKeyIDSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.SecretAccessKeySecretRef != nil {
+		in, out := &in.SecretAccessKeySecretRef, &out.SecretAccessKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Target.
+func (in *S3Target) DeepCopy() *S3Target {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Target)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StoreConfig) DeepCopyInto(out *StoreConfig) {
 	*out = *in
@@ -280,3 +397,54 @@ func (in *StoreConfigStatus) DeepCopy() *StoreConfigStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamHealth) DeepCopyInto(out *StreamHealth) {
+	*out = *in
+	if in.MaxReplicaLag != nil {
+		in, out := &in.MaxReplicaLag, &out.MaxReplicaLag
+		*out = new(uint64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamHealth.
+func (in *StreamHealth) DeepCopy() *StreamHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamPurge) DeepCopyInto(out *StreamPurge) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamPurge.
+func (in *StreamPurge) DeepCopy() *StreamPurge {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamPurge)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamPurgeStatus) DeepCopyInto(out *StreamPurgeStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamPurgeStatus.
+func (in *StreamPurgeStatus) DeepCopy() *StreamPurgeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamPurgeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
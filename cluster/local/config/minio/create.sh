@@ -0,0 +1,5 @@
+#!/bin/bash
+set -e
+kubectl apply -f manifest.yaml
+kubectl rollout status --watch --timeout=120s deployment -n minio minio
+kubectl wait --for=condition=Complete job -l app=minio-buckets -n minio --timeout=120s
@@ -1,8 +1,6 @@
 package consumer_test
 
 import (
-	"fmt"
-
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/nats-io/nats.go"
 	. "github.com/onsi/ginkgo/v2"
@@ -62,16 +60,9 @@ var _ = Describe("Consumer E2E tests", func() {
 				consumer.Spec.ForProvider.Config.SetDefaults(consumerinternalv1.ConsumerTypeNone)
 				consumer, err = utils.UnmarshalAnyYaml("manifests/consumer/invalid_config.yaml", consumer)
 				Expect(err).To(BeNil())
+				// The validating webhook rejects the consumer when it is applied.
 				err = f.CreateConsumer(consumer)
-				Expect(err).To(BeNil())
-				condition := &v1.Condition{
-					Type:    v1.TypeSynced,
-					Status:  corev1.ConditionFalse,
-					Reason:  v1.ReasonReconcileError,
-					Message: fmt.Sprintf("create failed: %s", errors.PushAndPullConsumerError.Error()),
-				}
-				err = f.WaitForConsumerCondition(consumer.ObjectMeta.Name, condition)
-				Expect(err).To(BeNil())
+				Expect(err).To(MatchError(ContainSubstring(errors.PushAndPullConsumerError.Error())))
 			})
 		})
 	})
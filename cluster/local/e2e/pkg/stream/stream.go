@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	streamsv1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
 	utils "github.com/edgefarm/provider-nats/cluster/local/e2e/pkg/utils"
 	"github.com/edgefarm/provider-nats/internal/clients/nats"
 )
@@ -81,15 +82,15 @@ var _ = Describe("Stream E2E tests", func() {
 				streamOld, err := f.GetStream("main-acc1")
 				Expect(err).To(BeNil())
 				streamNew := streamOld.DeepCopy()
-				streamNew.Spec.ForProvider.Config.MaxBytes = 402400
+				streamNew.Spec.ForProvider.Config.MaxBytes = apisv1alpha1.NewByteQuantity(402400)
 				streamNew.Spec.ForProvider.Config.Discard = "New"
 				err = f.UpdateStream(streamNew)
 				Expect(err).To(BeNil())
 				err = f.WaitForStreamSyncAndReady(streamNew.ObjectMeta.Name)
 				Expect(err).To(BeNil())
 
-				Expect(streamOld.Spec.ForProvider.Config.MaxBytes).Should(BeIdenticalTo(int64(102400)))
-				Expect(streamNew.Spec.ForProvider.Config.MaxBytes).Should(BeIdenticalTo(int64(402400)))
+				Expect(streamOld.Spec.ForProvider.Config.MaxBytes).Should(BeIdenticalTo(apisv1alpha1.NewByteQuantity(102400)))
+				Expect(streamNew.Spec.ForProvider.Config.MaxBytes).Should(BeIdenticalTo(apisv1alpha1.NewByteQuantity(402400)))
 				Expect(streamOld.Spec.ForProvider.Config.Discard).Should(BeIdenticalTo("Old"))
 				Expect(streamNew.Spec.ForProvider.Config.Discard).Should(BeIdenticalTo("New"))
 			})
@@ -215,6 +215,8 @@ echo_step "Provision nats credentials"
 ( cd ${projectdir}/cluster/local/config/nsc ; ./create.sh )
 echo_step "Deploy leaf-nats servers"
 ( cd ${projectdir}/cluster/local/config/leaf-nats ; ./create.sh )
+echo_step "Deploy minio as S3 target for stream backups"
+( cd ${projectdir}/cluster/local/config/minio ; ./create.sh )
 echo_step "Deploy providerconfigs"
 ( cd ${projectdir}/cluster/local/e2e/manifests/ ; kubectl apply -f providerconfig )
 
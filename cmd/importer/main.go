@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/edgefarm/provider-nats/apis"
+	"github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+	"github.com/edgefarm/provider-nats/internal/importer"
+)
+
+func main() {
+	var (
+		app            = kingpin.New(filepath.Base(os.Args[0]), "Generate managed resources for existing NATS Jetstream streams and consumers.").DefaultEnvars()
+		providerConfig = app.Flag("provider-config", "Name of the ProviderConfig used to connect to the NATS server.").Default("default").String()
+		domain         = app.Flag("domain", "Jetstream domain to import streams and consumers from.").Default("").String()
+		apiPrefix      = app.Flag("api-prefix", "Jetstream API prefix to import streams and consumers from, e.g. of another account. Cannot be combined with --domain.").Default("").String()
+		deletionPolicy = app.Flag("deletion-policy", "Deletion policy of the generated managed resources.").Default(string(xpv1.DeletionOrphan)).Enum(string(xpv1.DeletionOrphan), string(xpv1.DeletionDelete))
+		mgmtPolicy     = app.Flag("management-policy", "Management policy of the generated managed resources. ObserveOnly never updates the imported streams and consumers.").Default(string(v1alpha1.ManagementObserveOnly)).Enum(string(v1alpha1.ManagementObserveOnly), string(v1alpha1.ManagementOrphanOnDelete), string(v1alpha1.ManagementFullControl))
+	)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	ctx := context.Background()
+
+	cfg, err := ctrl.GetConfig()
+	kingpin.FatalIfError(err, "Cannot get API server rest config")
+
+	scheme := runtime.NewScheme()
+	kingpin.FatalIfError(clientgoscheme.AddToScheme(scheme), "Cannot add Kubernetes APIs to scheme")
+	kingpin.FatalIfError(apis.AddToScheme(scheme), "Cannot add NATS APIs to scheme")
+	kube, err := client.New(cfg, client.Options{Scheme: scheme})
+	kingpin.FatalIfError(err, "Cannot create Kubernetes client")
+
+	pc := &v1alpha1.ProviderConfig{}
+	kingpin.FatalIfError(kube.Get(ctx, types.NamespacedName{Name: *providerConfig}, pc), "Cannot get ProviderConfig")
+
+	cd := pc.Spec.Credentials
+	creds, err := resource.CommonCredentialExtractor(ctx, cd.Source, kube, cd.CommonCredentialSelectors)
+	kingpin.FatalIfError(err, "Cannot get credentials")
+
+	opts, err := nats.GetConnectOptions(ctx, kube, pc)
+	kingpin.FatalIfError(err, "Cannot get connect options")
+
+	c, err := nats.NewClient(creds, opts)
+	kingpin.FatalIfError(err, "Cannot connect to NATS server")
+	defer c.Disconnect()
+
+	res, err := importer.Import(ctx, c, importer.Options{
+		ProviderConfig:   *providerConfig,
+		Domain:           *domain,
+		APIPrefix:        *apiPrefix,
+		DeletionPolicy:   xpv1.DeletionPolicy(*deletionPolicy),
+		ManagementPolicy: v1alpha1.ManagementPolicy(*mgmtPolicy),
+	})
+	kingpin.FatalIfError(err, "Cannot import streams and consumers")
+
+	out, err := res.YAML()
+	kingpin.FatalIfError(err, "Cannot render managed resources")
+	fmt.Print(string(out))
+	fmt.Fprintf(os.Stderr, "Imported %s\n", res)
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
@@ -37,9 +38,12 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/edgefarm/provider-nats/apis"
+	consumerv1alpha1 "github.com/edgefarm/provider-nats/apis/consumer/v1alpha1"
+	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
 	"github.com/edgefarm/provider-nats/apis/v1alpha1"
 	nats "github.com/edgefarm/provider-nats/internal/controller"
 	"github.com/edgefarm/provider-nats/internal/controller/features"
+	natsmetrics "github.com/edgefarm/provider-nats/internal/metrics"
 )
 
 func main() {
@@ -54,6 +58,7 @@ func main() {
 
 		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for ExternalSecretStores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
+		webhookTLSCertDir          = app.Flag("webhook-tls-cert-dir", "The directory of TLS certificate that will be used by the webhook server. There should be tls.crt and tls.key files. The webhooks are disabled if empty.").Envar("WEBHOOK_TLS_CERT_DIR").String()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -71,6 +76,7 @@ func main() {
 
 	mgr, err := ctrl.NewManager(ratelimiter.LimitRESTConfig(cfg, *maxReconcileRate), ctrl.Options{
 		SyncPeriod: syncInterval,
+		CertDir:    *webhookTLSCertDir,
 
 		// controller-runtime uses both ConfigMaps and Leases for leader
 		// election by default. Leases expire after 15 seconds, with a
@@ -116,5 +122,10 @@ func main() {
 	}
 
 	kingpin.FatalIfError(nats.Setup(mgr, o), "Cannot setup NATS controllers")
+	kingpin.FatalIfError(metrics.Registry.Register(natsmetrics.NewCollector(mgr.GetClient())), "Cannot register stream and consumer metrics")
+	if *webhookTLSCertDir != "" {
+		kingpin.FatalIfError((&streamv1alpha1.Stream{}).SetupWebhookWithManager(mgr), "Cannot setup Stream webhook")
+		kingpin.FatalIfError((&consumerv1alpha1.Consumer{}).SetupWebhookWithManager(mgr), "Cannot setup Consumer webhook")
+	}
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
@@ -0,0 +1,67 @@
+//go:build generate
+// +build generate
+
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// crdconversion enables the conversion webhook for the given CRD manifests.
+// controller-gen does not support configuring the conversion strategy, so it
+// is patched into the generated manifests. The webhook service is filled in
+// by Crossplane when the provider package is installed.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+func main() {
+	for _, path := range os.Args[1:] {
+		if err := patch(path); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot patch %s: %v\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func patch(path string) error {
+	b, err := os.ReadFile(path) // #nosec G304 -- paths are given by go:generate
+	if err != nil {
+		return err
+	}
+	crd := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &crd); err != nil {
+		return err
+	}
+	spec, ok := crd["spec"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("manifest has no spec")
+	}
+	spec["conversion"] = map[string]interface{}{
+		"strategy": "Webhook",
+		"webhook": map[string]interface{}{
+			"conversionReviewVersions": []string{"v1"},
+		},
+	}
+	out, err := yaml.Marshal(crd)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte("---\n"), bytes.TrimPrefix(out, []byte("---\n"))...), 0o600)
+}
@@ -0,0 +1,101 @@
+package nats
+
+import (
+	"fmt"
+
+	natsjwt "github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nkeys"
+)
+
+const (
+	// AuthUserJWT authenticates with a user JWT and the seed of the user.
+	AuthUserJWT = "UserJWT"
+	// AuthCredsFile authenticates with the contents of a .creds file.
+	AuthCredsFile = "CredsFile"
+	// AuthNKey authenticates with the seed of a user NKey.
+	AuthNKey = "NKey"
+	// AuthUserPassword authenticates with a username and password.
+	AuthUserPassword = "UserPassword"
+	// AuthToken authenticates with a token.
+	AuthToken = "Token"
+	// AuthNone does not authenticate.
+	AuthNone = "None"
+)
+
+// identity is the authenticated identity of a connection.
+type identity struct {
+	userPublicKey    string
+	accountPublicKey string
+}
+
+// authOptions returns the NATS options to authenticate with the given method
+// and the identity of the connection as far as it is known for the method.
+func authOptions(auth string, config *Config) ([]nats.Option, *identity, error) {
+	switch auth {
+	case AuthUserJWT, "":
+		if config.JWT == "" || config.SeedKey == "" {
+			return nil, nil, missingFields(auth, "jwt", "seed_key")
+		}
+		return userJWTOptions(config.JWT, config.SeedKey)
+	case AuthCredsFile:
+		if config.Creds == "" {
+			return nil, nil, missingFields(auth, "creds")
+		}
+		jwt, err := natsjwt.ParseDecoratedJWT([]byte(config.Creds))
+		if err != nil {
+			return nil, nil, err
+		}
+		kp, err := natsjwt.ParseDecoratedUserNKey([]byte(config.Creds))
+		if err != nil {
+			return nil, nil, err
+		}
+		seed, err := kp.Seed()
+		if err != nil {
+			return nil, nil, err
+		}
+		return userJWTOptions(jwt, string(seed))
+	case AuthNKey:
+		if config.SeedKey == "" {
+			return nil, nil, missingFields(auth, "seed_key")
+		}
+		kp, err := nkeys.FromSeed([]byte(config.SeedKey))
+		if err != nil {
+			return nil, nil, err
+		}
+		pub, err := kp.PublicKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		return []nats.Option{nats.Nkey(pub, kp.Sign)}, &identity{userPublicKey: pub}, nil
+	case AuthUserPassword:
+		if config.User == "" || config.Password == "" {
+			return nil, nil, missingFields(auth, "user", "password")
+		}
+		return []nats.Option{nats.UserInfo(config.User, config.Password)}, &identity{}, nil
+	case AuthToken:
+		if config.Token == "" {
+			return nil, nil, missingFields(auth, "token")
+		}
+		return []nats.Option{nats.Token(config.Token)}, &identity{}, nil
+	case AuthNone:
+		return []nats.Option{}, &identity{}, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: unknown auth method %s", ErrNatsConfig, auth)
+	}
+}
+
+func userJWTOptions(jwt string, seed string) ([]nats.Option, *identity, error) {
+	accountPub, userPub, err := GetPublicKeys(jwt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []nats.Option{nats.UserJWTAndSeed(jwt, seed)}, &identity{userPublicKey: userPub, accountPublicKey: accountPub}, nil
+}
+
+func missingFields(auth string, fields ...string) error {
+	if auth == "" {
+		auth = AuthUserJWT
+	}
+	return fmt.Errorf("%w: auth method %s requires %v", ErrNatsConfig, auth, fields)
+}
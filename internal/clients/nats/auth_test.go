@@ -0,0 +1,71 @@
+package nats
+
+import (
+	"errors"
+	"testing"
+
+	natsjwt "github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	account, err := nkeys.CreateAccount()
+	assert.Nil(err)
+	accountPub, err := account.PublicKey()
+	assert.Nil(err)
+	user, err := nkeys.CreateUser()
+	assert.Nil(err)
+	userPub, err := user.PublicKey()
+	assert.Nil(err)
+	userSeed, err := user.Seed()
+	assert.Nil(err)
+
+	claims := natsjwt.NewUserClaims(userPub)
+	jwt, err := claims.Encode(account)
+	assert.Nil(err)
+	creds, err := natsjwt.FormatUserConfig(jwt, userSeed)
+	assert.Nil(err)
+
+	// UserJWT is the default auth method
+	opts, id, err := authOptions("", &Config{JWT: jwt, SeedKey: string(userSeed)})
+	assert.Nil(err)
+	assert.Len(opts, 1)
+	assert.Equal(userPub, id.userPublicKey)
+	assert.Equal(accountPub, id.accountPublicKey)
+
+	opts, id, err = authOptions(AuthCredsFile, &Config{Creds: string(creds)})
+	assert.Nil(err)
+	assert.Len(opts, 1)
+	assert.Equal(userPub, id.userPublicKey)
+	assert.Equal(accountPub, id.accountPublicKey)
+
+	opts, id, err = authOptions(AuthNKey, &Config{SeedKey: string(userSeed)})
+	assert.Nil(err)
+	assert.Len(opts, 1)
+	assert.Equal(userPub, id.userPublicKey)
+
+	opts, _, err = authOptions(AuthUserPassword, &Config{User: "user", Password: "secret"})
+	assert.Nil(err)
+	assert.Len(opts, 1)
+
+	opts, _, err = authOptions(AuthToken, &Config{Token: "secret"})
+	assert.Nil(err)
+	assert.Len(opts, 1)
+
+	opts, _, err = authOptions(AuthNone, &Config{})
+	assert.Nil(err)
+	assert.Empty(opts)
+
+	// negative tests
+	_, _, err = authOptions(AuthUserJWT, &Config{JWT: jwt})
+	assert.True(errors.Is(err, ErrNatsConfig))
+	_, _, err = authOptions(AuthUserPassword, &Config{User: "user"})
+	assert.True(errors.Is(err, ErrNatsConfig))
+	_, _, err = authOptions(AuthToken, &Config{})
+	assert.True(errors.Is(err, ErrNatsConfig))
+	_, _, err = authOptions("Kerberos", &Config{})
+	assert.True(errors.Is(err, ErrNatsConfig))
+}
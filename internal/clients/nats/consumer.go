@@ -1,106 +1,137 @@
 package nats
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"time"
 
-	"github.com/nats-io/jsm.go"
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 )
 
-// ConsumerList returns a list of consumer names for a given domain
-func ConsumerList(c *Client, domain string, stream string) ([]string, error) {
-	jsopts := []jsm.Option{}
-	if domain != "" {
-		jsopts = append(jsopts, jsm.WithDomain(domain))
-	}
+// ConsumerList returns a list of consumer names for a given domain and stream
+func (c *Client) ConsumerList(ctx context.Context, domain string, stream string) (names []string, err error) {
+	defer observeRequest(opConsumerList, time.Now(), &err)
 
-	c.conn.NewRespInbox()
-	mgr, err := jsm.New(c.conn, jsopts...)
+	js, err := c.jetStream(domain)
 	if err != nil {
 		return nil, err
 	}
 
-	names, err := mgr.ConsumerNames(stream)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	s, err := js.Stream(ctx, stream)
 	if err != nil {
 		return nil, err
 	}
+
+	names = []string{}
+	lister := s.ConsumerNames(ctx)
+	for name := range lister.Name() {
+		names = append(names, name)
+	}
+	if err := lister.Err(); err != nil {
+		return nil, err
+	}
 	return names, nil
 }
 
-// ConsumerInfo returns the consumer info for a given consumer name for a given domain and stream
+// ConsumerList returns a list of consumer names for a given domain and stream
+//
+// Deprecated: Use Client.ConsumerList.
+func ConsumerList(c *Client, domain string, stream string) ([]string, error) {
+	return c.ConsumerList(context.Background(), domain, stream)
+}
+
+// ConsumerInfo returns the consumer info for a given consumer name for a given domain and stream or nil if the consumer does not exist
+//
+// Deprecated: Use Client.ConsumerInfo, which takes the stream before the consumer.
 func ConsumerInfo(c *Client, domain string, consumer string, stream string) (*nats.ConsumerInfo, error) {
-	jsctx, err := c.conn.JetStream(nats.Domain(domain))
+	return c.ConsumerInfo(context.Background(), domain, stream, consumer)
+}
+
+// ConsumerInfo returns the consumer info for a given consumer name for a given domain and stream or nil if the consumer does not exist
+func (c *Client) ConsumerInfo(ctx context.Context, domain string, stream string, consumer string) (info *nats.ConsumerInfo, err error) {
+	defer observeRequest(opConsumerInfo, time.Now(), &err)
+
+	js, err := c.jetStream(domain)
 	if err != nil {
 		return nil, err
 	}
 
-	info, err := jsctx.ConsumerInfo(stream, consumer)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	cons, err := js.Consumer(ctx, stream, consumer)
 	if err != nil {
-		if errors.Is(err, nats.ErrConsumerNotFound) {
+		// A consumer cannot exist without its stream
+		if errors.Is(err, jetstream.ErrConsumerNotFound) || errors.Is(err, jetstream.ErrStreamNotFound) {
 			return nil, nil
 		}
 		return nil, err
 	}
 
-	return info, nil
+	return convert[nats.ConsumerInfo](cons.CachedInfo())
 }
 
 // CreateConsumer creates a new jetstream consumer with a given configuration for a given domain and stream
-func (c *Client) CreateConsumer(domain string, stream string, config *nats.ConsumerConfig) error {
-	jsOpts := []nats.JSOpt{}
-	if domain != "" {
-		jsOpts = append(jsOpts, nats.Domain(domain))
-	}
+func (c *Client) CreateConsumer(ctx context.Context, domain string, stream string, config *nats.ConsumerConfig) (err error) {
+	defer observeRequest(opConsumerCreate, time.Now(), &err)
 
-	jsctx, err := c.conn.JetStream(jsOpts...)
+	js, err := c.jetStream(domain)
 	if err != nil {
 		return err
 	}
-
-	_, err = jsctx.AddConsumer(stream, config)
+	cfg, err := convert[jetstream.ConsumerConfig](config)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	_, err = js.CreateConsumer(ctx, stream, *cfg)
+	return err
 }
 
 // DeleteConsumer deletes a jetstream consumer with a given name for a given domain and stream
-func (c *Client) DeleteConsumer(domain string, stream string, consumer string) error {
-	jsOpts := []nats.JSOpt{}
-	if domain != "" {
-		jsOpts = append(jsOpts, nats.Domain(domain))
-	}
+func (c *Client) DeleteConsumer(ctx context.Context, domain string, stream string, consumer string) (err error) {
+	defer observeRequest(opConsumerDelete, time.Now(), &err)
 
-	jsctx, err := c.conn.JetStream(jsOpts...)
+	js, err := c.jetStream(domain)
 	if err != nil {
 		return err
 	}
 
-	err = jsctx.DeleteConsumer(stream, consumer)
-	if err != nil {
-		return err
-	}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 
-	return nil
+	return js.DeleteConsumer(ctx, stream, consumer)
 }
 
 // UpdateConsumer updates a jetstream consumer with a given configuration for a given domain and stream
-func (c *Client) UpdateConsumer(domain string, stream string, config *nats.ConsumerConfig) error {
-	jsOpts := []nats.JSOpt{}
-	if domain != "" {
-		jsOpts = append(jsOpts, nats.Domain(domain))
-	}
+func (c *Client) UpdateConsumer(ctx context.Context, domain string, stream string, config *nats.ConsumerConfig) (err error) {
+	defer observeRequest(opConsumerUpdate, time.Now(), &err)
 
-	jsctx, err := c.conn.JetStream(jsOpts...)
+	js, err := c.jetStream(domain)
 	if err != nil {
 		return err
 	}
-
-	_, err = jsctx.UpdateConsumer(stream, config)
+	cfg, err := convert[jetstream.ConsumerConfig](config)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	_, err = js.UpdateConsumer(ctx, stream, *cfg)
+	return err
+}
+
+// PullSubject returns the subject clients request the next messages of a pull consumer from for a given JetStream API prefix
+func PullSubject(apiPrefix string, stream string, consumer string) string {
+	return fmt.Sprintf("%s.CONSUMER.MSG.NEXT.%s.%s", apiPrefix, stream, consumer)
 }
@@ -0,0 +1,21 @@
+package nats
+
+import (
+	"encoding/json"
+)
+
+// convert converts between the types of the legacy API of nats.go, which are
+// still used by the controllers, and their counterparts of the jetstream
+// package. Both mirror the JSON of the JetStream API, which is used for the
+// conversion.
+func convert[T any](in any) (*T, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	out := new(T)
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,70 @@
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config := &nats.StreamConfig{
+		Name:       "orders",
+		Subjects:   []string{"orders.>"},
+		Retention:  nats.WorkQueuePolicy,
+		Storage:    nats.MemoryStorage,
+		MaxAge:     time.Hour,
+		Duplicates: time.Minute,
+		Mirror:     &nats.StreamSource{Name: "upstream", Domain: "foo"},
+	}
+	js, err := streamConfig(config)
+	assert.Nil(err)
+	assert.Equal("orders", js.Name)
+	assert.Equal(jetstream.WorkQueuePolicy, js.Retention)
+	assert.Equal(jetstream.MemoryStorage, js.Storage)
+	assert.Equal(time.Hour, js.MaxAge)
+	assert.Equal("foo", js.Mirror.Domain)
+
+	back, err := convert[nats.StreamConfig](js)
+	assert.Nil(err)
+	assert.Equal(config.Retention, back.Retention)
+	assert.Equal(config.MaxAge, back.MaxAge)
+	assert.Equal(config.Subjects, back.Subjects)
+
+	info, err := convert[nats.ConsumerInfo](&jetstream.ConsumerInfo{
+		Stream:     "orders",
+		Name:       "shipping",
+		Config:     jetstream.ConsumerConfig{Durable: "shipping", AckPolicy: jetstream.AckExplicitPolicy, AckWait: 30 * time.Second},
+		NumPending: 4,
+	})
+	assert.Nil(err)
+	assert.Equal("shipping", info.Config.Durable)
+	assert.Equal(nats.AckExplicitPolicy, info.Config.AckPolicy)
+	assert.Equal(30*time.Second, info.Config.AckWait)
+	assert.Equal(uint64(4), info.NumPending)
+}
+
+func TestRequestOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	var opts *ConnectOptions
+	assert.Equal(apiTimeout, opts.requestTimeout())
+	assert.Equal("", opts.apiPrefix())
+
+	opts = &ConnectOptions{RequestTimeout: 10 * time.Second, APIPrefix: "$JS.hub.API"}
+	assert.Equal(10*time.Second, opts.requestTimeout())
+	assert.Equal("$JS.hub.API", opts.apiPrefix())
+
+	c := &Client{apiPrefix: opts.apiPrefix()}
+	assert.Equal("$JS.hub.API", c.APIPrefix(""))
+	assert.Equal("$JS.foo.API", c.APIPrefix("foo"))
+
+	scoped := c.WithAPIPrefix("$JS.tenant.API")
+	assert.Equal("$JS.tenant.API", scoped.APIPrefix(""))
+	assert.Equal("$JS.hub.API", c.APIPrefix(""))
+	assert.Equal(c, c.WithAPIPrefix(""))
+}
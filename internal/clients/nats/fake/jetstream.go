@@ -0,0 +1,448 @@
+// Package fake provides an in-memory implementation of the JetStream client
+// of the controllers, so that their reconcile logic can be tested without a
+// NATS server.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+)
+
+// Files BackupStream writes, named like the files of a snapshot of the NATS
+// server so that they can be stored in a snapshot store. The data file holds
+// the stream and its consumers, the metadata file its configuration and state.
+const (
+	dataFile = "stream.tar.s2"
+	metaFile = "backup.json"
+)
+
+// ErrPullRequiresAck is returned like by the NATS server for a pull consumer
+// without acknowledgements of a work queue stream.
+var ErrPullRequiresAck = &natsgo.APIError{Code: 400, ErrorCode: 10084, Description: "consumer in pull mode requires ack policy"}
+
+// Defaults of the NATS server applied to new streams and consumers.
+const (
+	defaultDuplicates    = 2 * time.Minute
+	defaultAckWait       = 30 * time.Second
+	defaultMaxAckPending = 1000
+	defaultMaxWaiting    = 512
+)
+
+type stream struct {
+	info      *natsgo.StreamInfo
+	consumers map[string]*natsgo.ConsumerInfo
+}
+
+// JetStream is an in-memory JetStream of an account with any number of
+// domains. It applies the defaults of the NATS server to the configuration of
+// new streams and consumers and returns the same errors as the NATS server for
+// missing or existing streams and consumers. It does not store messages, the
+// state of a stream can be set through Stream.
+type JetStream struct {
+	*account
+	// apiPrefix is the API prefix of requests without a domain, see WithAPIPrefix.
+	apiPrefix string
+}
+
+// account holds the streams of all JetStream APIs by their API prefix, so that
+// clients returned by WithAPIPrefix share them with the client they were
+// derived from.
+type account struct {
+	// Err is returned by every call if set, e.g. to simulate an unreachable server.
+	Err error
+
+	mu         sync.Mutex
+	connection nats.Connection
+	streams    map[string]map[string]*stream
+}
+
+var _ nats.JetStream = &JetStream{}
+
+// New returns an empty JetStream that reports the given connection.
+func New(connection nats.Connection) *JetStream {
+	return &JetStream{
+		account: &account{
+			connection: connection,
+			streams:    map[string]map[string]*stream{},
+		},
+	}
+}
+
+// Factory returns a JetStreamFactory that always returns j.
+func (j *JetStream) Factory() nats.JetStreamFactory {
+	return func(string, []byte, *nats.ConnectOptions) (nats.JetStream, error) {
+		return j, nil
+	}
+}
+
+// Stream returns the stored info of a stream or nil if the stream does not
+// exist. Changes to the info, e.g. to its state or cluster, are visible to the
+// code under test.
+func (j *JetStream) Stream(domain string, name string) *natsgo.StreamInfo {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s := j.streams[j.APIPrefix(domain)][name]
+	if s == nil {
+		return nil
+	}
+	return s.info
+}
+
+// Consumer returns the stored info of a consumer or nil if the consumer does
+// not exist. Changes to the info are visible to the code under test.
+func (j *JetStream) Consumer(domain string, stream string, name string) *natsgo.ConsumerInfo {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s := j.streams[j.APIPrefix(domain)][stream]
+	if s == nil {
+		return nil
+	}
+	return s.consumers[name]
+}
+
+func (j *JetStream) Connection() nats.Connection {
+	return j.connection
+}
+
+func (j *JetStream) APIPrefix(domain string) string {
+	if domain == "" && j.apiPrefix != "" {
+		return j.apiPrefix
+	}
+	return nats.APIPrefix(domain)
+}
+
+// WithAPIPrefix returns a JetStream that shares the streams of j. Streams
+// created through the prefix of a domain, e.g. $JS.foo.API, are streams of
+// that domain.
+func (j *JetStream) WithAPIPrefix(prefix string) nats.JetStream {
+	if prefix == "" {
+		return j
+	}
+	return &JetStream{account: j.account, apiPrefix: prefix}
+}
+
+func (j *JetStream) StreamList(ctx context.Context, domain string) ([]string, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return nil, j.Err
+	}
+	names := []string{}
+	for name := range j.streams[j.APIPrefix(domain)] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (j *JetStream) StreamInfo(ctx context.Context, domain string, name string) (*natsgo.StreamInfo, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return nil, j.Err
+	}
+	s := j.streams[j.APIPrefix(domain)][name]
+	if s == nil {
+		return nil, nil
+	}
+	info := *s.info
+	return &info, nil
+}
+
+func (j *JetStream) CreateStream(ctx context.Context, domain string, config *natsgo.StreamConfig) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return j.Err
+	}
+	if j.streams[j.APIPrefix(domain)][config.Name] != nil {
+		return natsgo.ErrStreamNameAlreadyInUse
+	}
+	j.addStream(domain, &natsgo.StreamInfo{
+		Config:  streamDefaults(*config),
+		Created: time.Now(),
+	})
+	return nil
+}
+
+func (j *JetStream) UpdateStream(ctx context.Context, domain string, config *natsgo.StreamConfig) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return j.Err
+	}
+	s := j.streams[j.APIPrefix(domain)][config.Name]
+	if s == nil {
+		return natsgo.ErrStreamNotFound
+	}
+	s.info.Config = streamDefaults(*config)
+	return nil
+}
+
+func (j *JetStream) DeleteStream(ctx context.Context, domain string, name string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return j.Err
+	}
+	if j.streams[j.APIPrefix(domain)][name] == nil {
+		return natsgo.ErrStreamNotFound
+	}
+	delete(j.streams[j.APIPrefix(domain)], name)
+	return nil
+}
+
+// PurgeStream purges all messages of the stream but the number of messages
+// to keep. Purging by subject or sequence is not supported.
+func (j *JetStream) PurgeStream(ctx context.Context, domain string, name string, req *natsgo.StreamPurgeRequest) (uint64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return 0, j.Err
+	}
+	s := j.streams[j.APIPrefix(domain)][name]
+	if s == nil {
+		return 0, natsgo.ErrStreamNotFound
+	}
+	state := &s.info.State
+	purged := state.Msgs
+	if req != nil && req.Keep > 0 {
+		if req.Keep >= state.Msgs {
+			return 0, nil
+		}
+		purged = state.Msgs - req.Keep
+	}
+	state.Msgs -= purged
+	state.FirstSeq += purged
+	return purged, nil
+}
+
+// BackupStream writes the info of the stream and its consumers into the
+// directory.
+func (j *JetStream) BackupStream(ctx context.Context, domain string, name string, dir string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return j.Err
+	}
+	s := j.streams[j.APIPrefix(domain)][name]
+	if s == nil {
+		return natsgo.ErrStreamNotFound
+	}
+	data, err := json.Marshal(snapshot{Stream: s.info, Consumers: s.consumers})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, dataFile), data, 0600); err != nil {
+		return err
+	}
+	meta, err := json.Marshal(metadata{Config: s.info.Config, State: s.info.State})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, metaFile), meta, 0600)
+}
+
+// RestoreStream restores a stream written by BackupStream.
+func (j *JetStream) RestoreStream(ctx context.Context, domain string, name string, dir string) (uint64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return 0, j.Err
+	}
+	if j.streams[j.APIPrefix(domain)][name] != nil {
+		return 0, natsgo.ErrStreamNameAlreadyInUse
+	}
+	data, err := os.ReadFile(filepath.Join(dir, dataFile))
+	if err != nil {
+		return 0, err
+	}
+	snap := snapshot{}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return 0, err
+	}
+	if snap.Stream == nil || snap.Stream.Config.Name != name {
+		return 0, natsgo.ErrStreamNotFound
+	}
+	s := j.addStream(domain, snap.Stream)
+	for consumer, info := range snap.Consumers {
+		s.consumers[consumer] = info
+	}
+	return snap.Stream.State.Msgs, nil
+}
+
+func (j *JetStream) ConsumerList(ctx context.Context, domain string, stream string) ([]string, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return nil, j.Err
+	}
+	s := j.streams[j.APIPrefix(domain)][stream]
+	if s == nil {
+		return nil, natsgo.ErrStreamNotFound
+	}
+	names := []string{}
+	for name := range s.consumers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (j *JetStream) ConsumerInfo(ctx context.Context, domain string, stream string, consumer string) (*natsgo.ConsumerInfo, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return nil, j.Err
+	}
+	s := j.streams[j.APIPrefix(domain)][stream]
+	if s == nil || s.consumers[consumer] == nil {
+		return nil, nil
+	}
+	info := *s.consumers[consumer]
+	return &info, nil
+}
+
+func (j *JetStream) CreateConsumer(ctx context.Context, domain string, stream string, config *natsgo.ConsumerConfig) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return j.Err
+	}
+	s := j.streams[j.APIPrefix(domain)][stream]
+	if s == nil {
+		return natsgo.ErrStreamNotFound
+	}
+	c := consumerDefaults(*config)
+	if s.consumers[c.Name] != nil {
+		return natsgo.ErrConsumerNameAlreadyInUse
+	}
+	if c.DeliverSubject == "" && c.AckPolicy == natsgo.AckNonePolicy && s.info.Config.Retention == natsgo.WorkQueuePolicy {
+		return ErrPullRequiresAck
+	}
+	s.consumers[c.Name] = &natsgo.ConsumerInfo{
+		Stream:  stream,
+		Name:    c.Name,
+		Config:  c,
+		Created: time.Now(),
+	}
+	s.info.State.Consumers = len(s.consumers)
+	return nil
+}
+
+func (j *JetStream) UpdateConsumer(ctx context.Context, domain string, stream string, config *natsgo.ConsumerConfig) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return j.Err
+	}
+	s := j.streams[j.APIPrefix(domain)][stream]
+	if s == nil {
+		return natsgo.ErrStreamNotFound
+	}
+	c := consumerDefaults(*config)
+	info := s.consumers[c.Name]
+	if info == nil {
+		return natsgo.ErrConsumerNotFound
+	}
+	info.Config = c
+	return nil
+}
+
+func (j *JetStream) DeleteConsumer(ctx context.Context, domain string, stream string, consumer string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.Err != nil {
+		return j.Err
+	}
+	s := j.streams[j.APIPrefix(domain)][stream]
+	if s == nil {
+		return natsgo.ErrStreamNotFound
+	}
+	if s.consumers[consumer] == nil {
+		return natsgo.ErrConsumerNotFound
+	}
+	delete(s.consumers, consumer)
+	s.info.State.Consumers = len(s.consumers)
+	return nil
+}
+
+// snapshot is the content of the data file written by BackupStream.
+type snapshot struct {
+	Stream    *natsgo.StreamInfo              `json:"stream"`
+	Consumers map[string]*natsgo.ConsumerInfo `json:"consumers"`
+}
+
+// metadata is the content of the metadata file written by BackupStream.
+type metadata struct {
+	Config natsgo.StreamConfig `json:"config"`
+	State  natsgo.StreamState  `json:"state"`
+}
+
+func (j *JetStream) addStream(domain string, info *natsgo.StreamInfo) *stream {
+	prefix := j.APIPrefix(domain)
+	if j.streams[prefix] == nil {
+		j.streams[prefix] = map[string]*stream{}
+	}
+	s := &stream{
+		info:      info,
+		consumers: map[string]*natsgo.ConsumerInfo{},
+	}
+	j.streams[prefix][info.Config.Name] = s
+	return s
+}
+
+func streamDefaults(config natsgo.StreamConfig) natsgo.StreamConfig {
+	unlimited := func(v *int64) {
+		if *v == 0 {
+			*v = -1
+		}
+	}
+	unlimited(&config.MaxMsgs)
+	unlimited(&config.MaxBytes)
+	unlimited(&config.MaxMsgsPerSubject)
+	if config.MaxConsumers == 0 {
+		config.MaxConsumers = -1
+	}
+	if config.MaxMsgSize == 0 {
+		config.MaxMsgSize = -1
+	}
+	if config.Replicas == 0 {
+		config.Replicas = 1
+	}
+	if config.Duplicates == 0 {
+		config.Duplicates = defaultDuplicates
+	}
+	if len(config.Subjects) == 0 {
+		config.Subjects = []string{config.Name}
+	}
+	return config
+}
+
+func consumerDefaults(config natsgo.ConsumerConfig) natsgo.ConsumerConfig {
+	if config.Name == "" {
+		config.Name = config.Durable
+	}
+	if config.AckWait == 0 {
+		config.AckWait = defaultAckWait
+	}
+	if config.MaxDeliver == 0 {
+		config.MaxDeliver = -1
+	}
+	if config.MaxAckPending == 0 && config.AckPolicy != natsgo.AckNonePolicy {
+		config.MaxAckPending = defaultMaxAckPending
+	}
+	if config.MaxWaiting == 0 && config.DeliverSubject == "" {
+		config.MaxWaiting = defaultMaxWaiting
+	}
+	return config
+}
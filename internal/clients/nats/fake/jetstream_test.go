@@ -0,0 +1,133 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+)
+
+func TestStreams(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	js := New(nats.Connection{Address: "nats://nats:4222"})
+	assert.Equal("nats://nats:4222", js.Connection().Address)
+
+	info, err := js.StreamInfo(ctx, "", "orders")
+	assert.Nil(err)
+	assert.Nil(info)
+	assert.Equal(natsgo.ErrStreamNotFound, js.UpdateStream(ctx, "", &natsgo.StreamConfig{Name: "orders"}))
+
+	assert.Nil(js.CreateStream(ctx, "", &natsgo.StreamConfig{Name: "orders", Subjects: []string{"orders.>"}}))
+	assert.Equal(natsgo.ErrStreamNameAlreadyInUse, js.CreateStream(ctx, "", &natsgo.StreamConfig{Name: "orders"}))
+	info, err = js.StreamInfo(ctx, "", "orders")
+	assert.Nil(err)
+	assert.Equal(int64(-1), info.Config.MaxMsgs)
+	assert.Equal(1, info.Config.Replicas)
+
+	names, err := js.StreamList(ctx, "")
+	assert.Nil(err)
+	assert.Equal([]string{"orders"}, names)
+	names, err = js.StreamList(ctx, "foo")
+	assert.Nil(err)
+	assert.Empty(names)
+
+	assert.Nil(js.DeleteStream(ctx, "", "orders"))
+	assert.Equal(natsgo.ErrStreamNotFound, js.DeleteStream(ctx, "", "orders"))
+}
+
+func TestPurgeStream(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	js := New(nats.Connection{})
+	assert.Nil(js.CreateStream(ctx, "", &natsgo.StreamConfig{Name: "orders"}))
+	js.Stream("", "orders").State = natsgo.StreamState{Msgs: 10, FirstSeq: 1, LastSeq: 10}
+
+	purged, err := js.PurgeStream(ctx, "", "orders", &natsgo.StreamPurgeRequest{Keep: 4})
+	assert.Nil(err)
+	assert.Equal(uint64(6), purged)
+	purged, err = js.PurgeStream(ctx, "", "orders", &natsgo.StreamPurgeRequest{})
+	assert.Nil(err)
+	assert.Equal(uint64(4), purged)
+	assert.Equal(uint64(0), js.Stream("", "orders").State.Msgs)
+}
+
+func TestBackupRestoreStream(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	js := New(nats.Connection{})
+	assert.Nil(js.CreateStream(ctx, "", &natsgo.StreamConfig{Name: "orders"}))
+	assert.Nil(js.CreateConsumer(ctx, "", "orders", &natsgo.ConsumerConfig{Durable: "shipping"}))
+	js.Stream("", "orders").State.Msgs = 3
+
+	assert.Nil(js.BackupStream(ctx, "", "orders", dir))
+	_, err := js.RestoreStream(ctx, "", "orders", dir)
+	assert.Equal(natsgo.ErrStreamNameAlreadyInUse, err)
+
+	msgs, err := js.RestoreStream(ctx, "foo", "orders", dir)
+	assert.Nil(err)
+	assert.Equal(uint64(3), msgs)
+	names, err := js.ConsumerList(ctx, "foo", "orders")
+	assert.Nil(err)
+	assert.Equal([]string{"shipping"}, names)
+}
+
+func TestConsumers(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	js := New(nats.Connection{})
+	config := &natsgo.ConsumerConfig{Durable: "shipping", AckPolicy: natsgo.AckExplicitPolicy}
+	assert.Equal(natsgo.ErrStreamNotFound, js.CreateConsumer(ctx, "", "orders", config))
+
+	assert.Nil(js.CreateStream(ctx, "", &natsgo.StreamConfig{Name: "orders"}))
+	assert.Nil(js.CreateConsumer(ctx, "", "orders", config))
+	assert.Equal(natsgo.ErrConsumerNameAlreadyInUse, js.CreateConsumer(ctx, "", "orders", config))
+	assert.Equal(1, js.Stream("", "orders").State.Consumers)
+
+	info, err := js.ConsumerInfo(ctx, "", "orders", "shipping")
+	assert.Nil(err)
+	assert.Equal("shipping", info.Config.Name)
+	assert.Equal(-1, info.Config.MaxDeliver)
+
+	config.Description = "shipping"
+	assert.Nil(js.UpdateConsumer(ctx, "", "orders", config))
+	assert.Equal("shipping", js.Consumer("", "orders", "shipping").Config.Description)
+
+	assert.Nil(js.DeleteConsumer(ctx, "", "orders", "shipping"))
+	assert.Equal(natsgo.ErrConsumerNotFound, js.DeleteConsumer(ctx, "", "orders", "shipping"))
+	info, err = js.ConsumerInfo(ctx, "", "orders", "shipping")
+	assert.Nil(err)
+	assert.Nil(info)
+
+	assert.Nil(js.CreateStream(ctx, "", &natsgo.StreamConfig{Name: "jobs", Retention: natsgo.WorkQueuePolicy}))
+	assert.Equal(ErrPullRequiresAck, js.CreateConsumer(ctx, "", "jobs", &natsgo.ConsumerConfig{Durable: "worker", AckPolicy: natsgo.AckNonePolicy}))
+}
+
+func TestAPIPrefix(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	js := New(nats.Connection{})
+	tenant := js.WithAPIPrefix("$JS.tenant.API")
+	assert.Equal("$JS.tenant.API", tenant.APIPrefix(""))
+	assert.Equal("$JS.foo.API", tenant.APIPrefix("foo"))
+
+	assert.Nil(tenant.CreateStream(ctx, "", &natsgo.StreamConfig{Name: "orders"}))
+	info, err := js.StreamInfo(ctx, "", "orders")
+	assert.Nil(err)
+	assert.Nil(info)
+	names, err := tenant.StreamList(ctx, "")
+	assert.Nil(err)
+	assert.Equal([]string{"orders"}, names)
+
+	// The prefix of a domain addresses the streams of that domain
+	assert.Nil(js.WithAPIPrefix("$JS.foo.API").CreateStream(ctx, "", &natsgo.StreamConfig{Name: "orders"}))
+	assert.NotNil(js.Stream("foo", "orders"))
+
+	js.Err = natsgo.ErrConnectionClosed
+	_, err = tenant.StreamList(ctx, "")
+	assert.Equal(natsgo.ErrConnectionClosed, err)
+}
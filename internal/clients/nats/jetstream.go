@@ -0,0 +1,53 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// JetStream manages the streams and consumers of an account. It is implemented
+// by Client and by the in-memory fake of the fake package, which allows to test
+// the controllers without a NATS server. Every request is bounded by ctx and by
+// the request timeout of the ProviderConfig.
+type JetStream interface {
+	// Connection returns the server the client is connected to and the identity it is connected as.
+	Connection() Connection
+	// APIPrefix returns the JetStream API prefix of requests for a domain.
+	APIPrefix(domain string) string
+	// WithAPIPrefix returns a client for the JetStream API imported under prefix,
+	// e.g. from another account, which is used for requests without a domain.
+	WithAPIPrefix(prefix string) JetStream
+
+	StreamList(ctx context.Context, domain string) ([]string, error)
+	StreamInfo(ctx context.Context, domain string, stream string) (*nats.StreamInfo, error)
+	CreateStream(ctx context.Context, domain string, config *nats.StreamConfig) error
+	UpdateStream(ctx context.Context, domain string, config *nats.StreamConfig) error
+	DeleteStream(ctx context.Context, domain string, name string) error
+	PurgeStream(ctx context.Context, domain string, name string, req *nats.StreamPurgeRequest) (uint64, error)
+	BackupStream(ctx context.Context, domain string, name string, dir string) error
+	RestoreStream(ctx context.Context, domain string, name string, dir string) (uint64, error)
+
+	ConsumerList(ctx context.Context, domain string, stream string) ([]string, error)
+	ConsumerInfo(ctx context.Context, domain string, stream string, consumer string) (*nats.ConsumerInfo, error)
+	CreateConsumer(ctx context.Context, domain string, stream string, config *nats.ConsumerConfig) error
+	UpdateConsumer(ctx context.Context, domain string, stream string, config *nats.ConsumerConfig) error
+	DeleteConsumer(ctx context.Context, domain string, stream string, consumer string) error
+}
+
+var _ JetStream = &Client{}
+
+// JetStreamFactory returns the JetStream client of a ProviderConfig that
+// connects with the given credentials and connect options. Controllers get
+// their clients from a factory so that tests can inject a fake.
+type JetStreamFactory func(providerConfig string, creds []byte, opts *ConnectOptions) (JetStream, error)
+
+// JetStream is a JetStreamFactory that returns the cached client of a
+// ProviderConfig, see Get.
+func (p *Pool) JetStream(providerConfig string, creds []byte, opts *ConnectOptions) (JetStream, error) {
+	client, err := p.Get(providerConfig, creds, opts)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
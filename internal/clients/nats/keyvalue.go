@@ -0,0 +1,215 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	keyValueStreamPrefix = "KV_"
+)
+
+// KeyValueBucketName returns the name of a key/value bucket that may be given
+// as the name of the stream backing it
+func KeyValueBucketName(name string) string {
+	return strings.TrimPrefix(name, keyValueStreamPrefix)
+}
+
+// KeyValueStreamName returns the name of the stream backing a key/value bucket
+func KeyValueStreamName(bucket string) string {
+	return keyValueStreamPrefix + KeyValueBucketName(bucket)
+}
+
+// KeyValueInfo returns the info of the stream backing a key/value bucket for a given domain
+func KeyValueInfo(ctx context.Context, c *Client, domain string, bucket string) (*nats.StreamInfo, error) {
+	return c.StreamInfo(ctx, domain, KeyValueStreamName(bucket))
+}
+
+// KeyValueConfigFromStream returns the key/value configuration of a bucket from its backing stream configuration
+func KeyValueConfigFromStream(bucket string, config *nats.StreamConfig) *nats.KeyValueConfig {
+	kv := &nats.KeyValueConfig{
+		Bucket:       KeyValueBucketName(bucket),
+		Description:  config.Description,
+		MaxValueSize: config.MaxMsgSize,
+		History:      keyValueHistory(config.MaxMsgsPerSubject),
+		TTL:          config.MaxAge,
+		MaxBytes:     config.MaxBytes,
+		Storage:      config.Storage,
+		Replicas:     config.Replicas,
+		Placement:    config.Placement,
+		RePublish:    config.RePublish,
+	}
+	if config.Mirror != nil {
+		m := *config.Mirror
+		m.Name = KeyValueBucketName(m.Name)
+		kv.Mirror = &m
+	}
+	for _, source := range config.Sources {
+		s := *source
+		s.Name = KeyValueBucketName(s.Name)
+		kv.Sources = append(kv.Sources, &s)
+	}
+	return kv
+}
+
+// keyValueHistory returns the history of a bucket from the maximum number of
+// messages per subject of its backing stream. Unlimited and larger values are
+// clamped to the largest history of a bucket.
+func keyValueHistory(maxMsgsPerSubject int64) uint8 {
+	switch {
+	case maxMsgsPerSubject < 0 || maxMsgsPerSubject > nats.KeyValueMaxHistory:
+		return nats.KeyValueMaxHistory
+	case maxMsgsPerSubject == 0:
+		return 1
+	}
+	return uint8(maxMsgsPerSubject)
+}
+
+// CreateKeyValue creates a new jetstream key/value bucket with a given configuration for a given domain
+func (c *Client) CreateKeyValue(ctx context.Context, domain string, config *nats.KeyValueConfig) (err error) {
+	defer observeRequest(opKeyValueCreate, time.Now(), &err)
+
+	js, err := c.jetStream(domain)
+	if err != nil {
+		return err
+	}
+	cfg, err := keyValueConfig(config)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	_, err = js.CreateKeyValue(ctx, *cfg)
+	return err
+}
+
+// UpdateKeyValue updates a jetstream key/value bucket with a given configuration for a given domain.
+// The bucket is updated by updating the stream backing the bucket.
+func (c *Client) UpdateKeyValue(ctx context.Context, domain string, config *nats.KeyValueConfig) (err error) {
+	defer observeRequest(opKeyValueUpdate, time.Now(), &err)
+
+	js, err := c.jetStream(domain)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	s, err := js.Stream(ctx, KeyValueStreamName(config.Bucket))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrStreamNotFound) {
+			return nats.ErrBucketNotFound
+		}
+		return err
+	}
+	current, err := convert[nats.StreamConfig](s.CachedInfo().Config)
+	if err != nil {
+		return err
+	}
+
+	applyKeyValueConfig(config, current)
+	cfg, err := streamConfig(current)
+	if err != nil {
+		return err
+	}
+
+	_, err = js.UpdateStream(ctx, *cfg)
+	return err
+}
+
+// DeleteKeyValue deletes a jetstream key/value bucket with a given name for a given domain
+func (c *Client) DeleteKeyValue(ctx context.Context, domain string, bucket string) (err error) {
+	defer observeRequest(opKeyValueDelete, time.Now(), &err)
+
+	js, err := c.jetStream(domain)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	return js.DeleteKeyValue(ctx, KeyValueBucketName(bucket))
+}
+
+// keyValueConfig converts a key/value configuration into its counterpart of
+// the jetstream package. The domains of the mirror and sources are copied
+// explicitly, see streamConfig.
+func keyValueConfig(config *nats.KeyValueConfig) (*jetstream.KeyValueConfig, error) {
+	cfg, err := convert[jetstream.KeyValueConfig](config)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Bucket = KeyValueBucketName(config.Bucket)
+	if config.Mirror != nil && cfg.Mirror != nil {
+		cfg.Mirror.Domain = config.Mirror.Domain
+	}
+	for i, source := range config.Sources {
+		if source != nil && i < len(cfg.Sources) && cfg.Sources[i] != nil {
+			cfg.Sources[i].Domain = source.Domain
+		}
+	}
+	return cfg, nil
+}
+
+// applyKeyValueConfig applies the key/value configuration to the backing stream
+// configuration the same way nats.go does when creating a bucket.
+func applyKeyValueConfig(in *nats.KeyValueConfig, out *nats.StreamConfig) {
+	history := int64(1)
+	if in.History > 0 {
+		history = int64(in.History)
+	}
+	replicas := in.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+	maxBytes := in.MaxBytes
+	if maxBytes == 0 {
+		maxBytes = -1
+	}
+	maxMsgSize := in.MaxValueSize
+	if maxMsgSize == 0 {
+		maxMsgSize = -1
+	}
+	duplicateWindow := 2 * time.Minute
+	if in.TTL > 0 && in.TTL < duplicateWindow {
+		duplicateWindow = in.TTL
+	}
+
+	out.Description = in.Description
+	out.MaxMsgsPerSubject = history
+	out.MaxBytes = maxBytes
+	out.MaxAge = in.TTL
+	out.MaxMsgSize = maxMsgSize
+	out.Storage = in.Storage
+	out.Replicas = replicas
+	out.Placement = in.Placement
+	out.RePublish = in.RePublish
+	out.Duplicates = duplicateWindow
+
+	out.Mirror = nil
+	out.Sources = nil
+	if in.Mirror != nil {
+		m := *in.Mirror
+		m.Name = KeyValueStreamName(m.Name)
+		out.Mirror = &m
+	} else {
+		for _, source := range in.Sources {
+			s := *source
+			s.Name = KeyValueStreamName(s.Name)
+			out.Sources = append(out.Sources, &s)
+		}
+	}
+	if out.Mirror == nil && len(out.Sources) == 0 && len(out.Subjects) == 0 {
+		out.Subjects = []string{fmt.Sprintf("$KV.%s.>", KeyValueBucketName(in.Bucket))}
+	}
+}
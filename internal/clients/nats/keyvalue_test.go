@@ -0,0 +1,52 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyValueBucketName(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("config", KeyValueBucketName("config"))
+	assert.Equal("config", KeyValueBucketName("KV_config"))
+	assert.Equal("KV_config", KeyValueStreamName("config"))
+	assert.Equal("KV_config", KeyValueStreamName("KV_config"))
+}
+
+func TestKeyValueConfigFromStream(t *testing.T) {
+	assert := assert.New(t)
+	for maxMsgsPerSubject, history := range map[int64]uint8{
+		-1:  nats.KeyValueMaxHistory,
+		0:   1,
+		5:   5,
+		64:  64,
+		300: nats.KeyValueMaxHistory,
+	} {
+		kv := KeyValueConfigFromStream("KV_config", &nats.StreamConfig{MaxMsgsPerSubject: maxMsgsPerSubject})
+		assert.Equal(history, kv.History, "max messages per subject %d", maxMsgsPerSubject)
+		assert.Equal("config", kv.Bucket)
+	}
+
+	kv := KeyValueConfigFromStream("config", &nats.StreamConfig{
+		Mirror:  &nats.StreamSource{Name: "KV_origin"},
+		Sources: []*nats.StreamSource{{Name: "KV_other"}, {Name: "plain"}},
+	})
+	assert.Equal("origin", kv.Mirror.Name)
+	assert.Equal("other", kv.Sources[0].Name)
+	assert.Equal("plain", kv.Sources[1].Name)
+}
+
+func TestDeleteKeyValueStreamName(t *testing.T) {
+	assert := assert.New(t)
+	c := runJetStream(t)
+
+	ctx := context.Background()
+	assert.Nil(c.CreateKeyValue(ctx, "", &nats.KeyValueConfig{Bucket: "config"}))
+	assert.Nil(c.DeleteKeyValue(ctx, "", "KV_config"))
+	info, err := KeyValueInfo(ctx, c, "", "config")
+	assert.Nil(err)
+	assert.Nil(info)
+}
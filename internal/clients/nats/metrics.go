@@ -0,0 +1,57 @@
+package nats
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Operations of the JetStream API calls that are measured.
+const (
+	opStreamList     = "stream_list"
+	opStreamInfo     = "stream_info"
+	opStreamCreate   = "stream_create"
+	opStreamUpdate   = "stream_update"
+	opStreamDelete   = "stream_delete"
+	opStreamBackup   = "stream_backup"
+	opStreamRestore  = "stream_restore"
+	opStreamPurge    = "stream_purge"
+	opConsumerList   = "consumer_list"
+	opConsumerInfo   = "consumer_info"
+	opConsumerCreate = "consumer_create"
+	opConsumerUpdate = "consumer_update"
+	opConsumerDelete = "consumer_delete"
+	opKeyValueCreate = "keyvalue_create"
+	opKeyValueUpdate = "keyvalue_update"
+	opKeyValueDelete = "keyvalue_delete"
+	opObjectCreate   = "objectstore_create"
+	opObjectUpdate   = "objectstore_update"
+	opObjectDelete   = "objectstore_delete"
+)
+
+var (
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "provider_nats_api_request_duration_seconds",
+		Help:    "Latency of JetStream API requests by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+	apiRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "provider_nats_api_request_errors_total",
+		Help: "Total number of failed JetStream API requests by operation.",
+	}, []string{"operation"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequestDuration, apiRequestErrors)
+}
+
+// observeRequest records the latency and the outcome of a JetStream API
+// request that started at start. It is deferred with a pointer to the named
+// error result of the request.
+func observeRequest(operation string, start time.Time, err *error) {
+	apiRequestDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if *err != nil {
+		apiRequestErrors.WithLabelValues(operation).Inc()
+	}
+}
@@ -1,12 +1,24 @@
 package nats
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
 	natsjwt "github.com/nats-io/jwt/v2"
-	"github.com/nats-io/nats.go"
 	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	jsAPIPrefix       = "$JS.API"
+	jsDomainAPIPrefix = "$JS.%s.API"
+
+	// apiTimeout is the default timeout of JetStream API requests, which is
+	// the same as the default of nats.go.
+	apiTimeout = 5 * time.Second
 )
 
 var (
@@ -18,17 +30,38 @@ type Config struct {
 	JWT string `json:"jwt"`
 	// SeedKey is the NATS users seed key to use for authentication.
 	SeedKey string `json:"seed_key"`
+	// Creds is the content of a NATS .creds file to use for authentication.
+	Creds string `json:"creds"`
+	// User is the username to use for authentication.
+	User string `json:"user"`
+	// Password is the password to use for authentication.
+	Password string `json:"password"`
+	// Token is the token to use for authentication.
+	Token string `json:"token"`
 	// Address is the NATS address to use for authentication.
 	Address string `json:"address"`
 }
 
-type Client struct {
-	conn             *natsgo.Conn
-	Address          string
-	UserPublicKey    string
+// Connection describes the server a client is connected to and the identity
+// it is connected as.
+type Connection struct {
+	// Address is the address of the NATS server.
+	Address string
+	// UserPublicKey is the public key of the user, if known for the auth method.
+	UserPublicKey string
+	// AccountPublicKey is the public key of the account, if known for the auth method.
 	AccountPublicKey string
 }
 
+type Client struct {
+	conn       *natsgo.Conn
+	connection Connection
+	// timeout is the timeout of a single JetStream API request.
+	timeout time.Duration
+	// apiPrefix is the JetStream API prefix of requests without a domain.
+	apiPrefix string
+}
+
 func GetPublicKeys(jwt string) (string, string, error) {
 	c, err := natsjwt.DecodeUserClaims(jwt)
 	if err != nil {
@@ -37,35 +70,96 @@ func GetPublicKeys(jwt string) (string, string, error) {
 	return c.Issuer, c.Subject, nil
 }
 
-func NewClient(creds []byte) (*Client, error) {
+// NewClient connects to the NATS server configured by the credentials using the given connect options.
+func NewClient(creds []byte, connectOpts *ConnectOptions) (*Client, error) {
 	var config Config
 	if err := jsonutil.DecodeJSON(creds, &config); err != nil {
 		return nil, err
 	}
 
-	if config.JWT == "" || config.SeedKey == "" || config.Address == "" {
+	if config.Address == "" {
 		return nil, ErrNatsConfig
 	}
 
-	opts := []nats.Option{}
-	opts = append(opts, nats.UserJWTAndSeed(config.JWT, config.SeedKey))
-	c, err := natsgo.Connect(config.Address, opts...)
+	opts, err := connectOpts.natsOptions()
 	if err != nil {
 		return nil, err
 	}
+	authOpts, id, err := authOptions(connectOpts.auth(), &config)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, authOpts...)
 
-	accountPub, userPub, err := GetPublicKeys(config.JWT)
+	c, err := natsgo.Connect(config.Address, opts...)
 	if err != nil {
 		return nil, err
 	}
+
 	return &Client{
-		conn:             c,
-		Address:          config.Address,
-		UserPublicKey:    userPub,
-		AccountPublicKey: accountPub,
+		conn: c,
+		connection: Connection{
+			Address:          config.Address,
+			UserPublicKey:    id.userPublicKey,
+			AccountPublicKey: id.accountPublicKey,
+		},
+		timeout:   connectOpts.requestTimeout(),
+		apiPrefix: connectOpts.apiPrefix(),
 	}, nil
 }
 
+// Connection returns the server the client is connected to and the identity it is connected as.
+func (c *Client) Connection() Connection {
+	return c.connection
+}
+
 func (c *Client) Disconnect() {
 	c.conn.Close()
 }
+
+// jetStream returns the JetStream API of a domain. Requests without a domain
+// use the API prefix of the ProviderConfig if set.
+func (c *Client) jetStream(domain string) (jetstream.JetStream, error) {
+	switch {
+	case domain != "":
+		return jetstream.NewWithDomain(c.conn, domain)
+	case c.apiPrefix != "":
+		return jetstream.NewWithAPIPrefix(c.conn, c.apiPrefix)
+	default:
+		return jetstream.New(c.conn)
+	}
+}
+
+// withTimeout bounds a JetStream API request by the request timeout of the
+// ProviderConfig.
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// APIPrefix returns the JetStream API prefix of requests for a domain.
+func (c *Client) APIPrefix(domain string) string {
+	if domain == "" && c.apiPrefix != "" {
+		return c.apiPrefix
+	}
+	return APIPrefix(domain)
+}
+
+// WithAPIPrefix returns a client that shares the connection of c and sends
+// requests without a domain to the JetStream API imported under prefix.
+// An empty prefix returns c.
+func (c *Client) WithAPIPrefix(prefix string) JetStream {
+	if prefix == "" {
+		return c
+	}
+	scoped := *c
+	scoped.apiPrefix = prefix
+	return &scoped
+}
+
+// APIPrefix returns the JetStream API prefix for a given domain
+func APIPrefix(domain string) string {
+	if domain == "" {
+		return jsAPIPrefix
+	}
+	return fmt.Sprintf(jsDomainAPIPrefix, domain)
+}
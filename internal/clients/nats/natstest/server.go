@@ -0,0 +1,282 @@
+// Package natstest runs in-process JetStream enabled NATS servers for tests of
+// the controllers, so that they can be tested without a kind cluster.
+//
+// The server runs in operator mode with an operator, a system account and an
+// account that has JetStream enabled, like the NATS servers provisioned by
+// nsc in cluster/local. Optional leaf node servers extend the account with a
+// JetStream domain each.
+package natstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	natsjwt "github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nats-server/v2/server"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/nats-io/nkeys"
+
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+)
+
+// readyTimeout is how long a server may take to accept connections and leaf
+// nodes may take to connect.
+const readyTimeout = 10 * time.Second
+
+// Option configures a Server.
+type Option func(*options)
+
+type options struct {
+	domains []string
+}
+
+// WithDomains starts a leaf node server with its own JetStream domain for
+// each domain. The leaf nodes connect to the account of the server.
+func WithDomains(domains ...string) Option {
+	return func(o *options) {
+		o.domains = append(o.domains, domains...)
+	}
+}
+
+// Server is a running JetStream enabled NATS server and its leaf nodes.
+type Server struct {
+	hub *server.Server
+
+	// AccountPublicKey is the public key of the account users connect to.
+	AccountPublicKey string
+	// UserPublicKey is the public key of the user returned by Creds.
+	UserPublicKey string
+
+	userJWT  string
+	userSeed []byte
+}
+
+// Run starts a server and the leaf nodes of its domains. The servers are shut
+// down when the test finishes.
+func Run(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	operator := newKeyPair(t, nkeys.CreateOperator)
+	system := newKeyPair(t, nkeys.CreateAccount)
+	account := newKeyPair(t, nkeys.CreateAccount)
+	user := newKeyPair(t, nkeys.CreateUser)
+
+	operatorPub := publicKey(t, operator)
+	systemPub := publicKey(t, system)
+	accountPub := publicKey(t, account)
+	userPub := publicKey(t, user)
+
+	operatorClaims := natsjwt.NewOperatorClaims(operatorPub)
+	operatorClaims.SystemAccount = systemPub
+	operatorJWT := encode(t, operatorClaims, operator)
+	trusted, err := natsjwt.DecodeOperatorClaims(operatorJWT)
+	if err != nil {
+		t.Fatalf("cannot decode operator JWT: %v", err)
+	}
+
+	resolver := &server.MemAccResolver{}
+	store(t, resolver, systemPub, encode(t, natsjwt.NewAccountClaims(systemPub), operator))
+	accountClaims := natsjwt.NewAccountClaims(accountPub)
+	accountClaims.Limits.JetStreamLimits = natsjwt.JetStreamLimits{
+		MemoryStorage: natsjwt.NoLimit,
+		DiskStorage:   natsjwt.NoLimit,
+		Streams:       natsjwt.NoLimit,
+		Consumer:      natsjwt.NoLimit,
+	}
+	store(t, resolver, accountPub, encode(t, accountClaims, operator))
+
+	userClaims := natsjwt.NewUserClaims(userPub)
+	userClaims.IssuerAccount = accountPub
+	userJWT := encode(t, userClaims, account)
+	userSeed, err := user.Seed()
+	if err != nil {
+		t.Fatalf("cannot get user seed: %v", err)
+	}
+
+	s := &Server{
+		AccountPublicKey: accountPub,
+		UserPublicKey:    userPub,
+		userJWT:          userJWT,
+		userSeed:         userSeed,
+	}
+
+	s.hub = start(t, &server.Options{
+		ServerName:       "hub",
+		Host:             "127.0.0.1",
+		Port:             server.RANDOM_PORT,
+		JetStream:        true,
+		StoreDir:         t.TempDir(),
+		TrustedOperators: []*natsjwt.OperatorClaims{trusted},
+		SystemAccount:    systemPub,
+		AccountResolver:  resolver,
+		LeafNode: server.LeafNodeOpts{
+			Host: "127.0.0.1",
+			Port: server.RANDOM_PORT,
+		},
+		NoSigs: true,
+	})
+
+	if len(o.domains) == 0 {
+		return s
+	}
+
+	credsFile := filepath.Join(t.TempDir(), "user.creds")
+	creds, err := natsjwt.FormatUserConfig(userJWT, userSeed)
+	if err != nil {
+		t.Fatalf("cannot format user credentials: %v", err)
+	}
+	if err := os.WriteFile(credsFile, creds, 0600); err != nil {
+		t.Fatalf("cannot write user credentials: %v", err)
+	}
+	varz, err := s.hub.Varz(nil)
+	if err != nil {
+		t.Fatalf("cannot get leaf node port: %v", err)
+	}
+	leafURL := &url.URL{Scheme: "nats", Host: fmt.Sprintf("127.0.0.1:%d", varz.LeafNode.Port)}
+
+	for _, domain := range o.domains {
+		start(t, &server.Options{
+			ServerName:      domain,
+			Host:            "127.0.0.1",
+			Port:            server.RANDOM_PORT,
+			JetStream:       true,
+			JetStreamDomain: domain,
+			StoreDir:        t.TempDir(),
+			LeafNode: server.LeafNodeOpts{
+				Remotes: []*server.RemoteLeafOpts{{
+					URLs:        []*url.URL{leafURL},
+					Credentials: credsFile,
+				}},
+			},
+			NoSigs: true,
+		})
+	}
+	s.waitForDomains(t, o.domains)
+
+	return s
+}
+
+// URL returns the client URL of the server.
+func (s *Server) URL() string {
+	return s.hub.ClientURL()
+}
+
+// Creds returns the credentials of a ProviderConfig that connects to the
+// server as a user of the account.
+func (s *Server) Creds() []byte {
+	creds, _ := json.Marshal(nats.Config{
+		JWT:     s.userJWT,
+		SeedKey: string(s.userSeed),
+		Address: s.URL(),
+	})
+	return creds
+}
+
+// Connect returns a plain connection to the server as a user of the account,
+// e.g. to prepare or inspect streams independently of the code under test.
+// The connection is closed when the test finishes.
+func (s *Server) Connect(t testing.TB) *natsgo.Conn {
+	t.Helper()
+	nc, err := natsgo.Connect(s.URL(), natsgo.UserJWTAndSeed(s.userJWT, string(s.userSeed)))
+	if err != nil {
+		t.Fatalf("cannot connect to NATS server: %v", err)
+	}
+	t.Cleanup(nc.Close)
+	return nc
+}
+
+// JetStream returns a JetStream context of a domain using Connect. The empty
+// domain addresses the JetStream of the server itself.
+func (s *Server) JetStream(t testing.TB, domain string) natsgo.JetStreamContext {
+	t.Helper()
+	opts := []natsgo.JSOpt{}
+	if domain != "" {
+		opts = append(opts, natsgo.Domain(domain))
+	}
+	js, err := s.Connect(t).JetStream(opts...)
+	if err != nil {
+		t.Fatalf("cannot get JetStream context: %v", err)
+	}
+	return js
+}
+
+// waitForDomains waits until the JetStream API of every domain is reachable
+// through the leaf node connections.
+func (s *Server) waitForDomains(t testing.TB, domains []string) {
+	t.Helper()
+	deadline := time.Now().Add(readyTimeout)
+	for _, domain := range domains {
+		js := s.JetStream(t, domain)
+		for {
+			_, err := js.AccountInfo()
+			if err == nil {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("JetStream domain %s is not reachable: %v", domain, err)
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+}
+
+func start(t testing.TB, opts *server.Options) *server.Server {
+	t.Helper()
+	s, err := server.NewServer(opts)
+	if err != nil {
+		t.Fatalf("cannot create NATS server %s: %v", opts.ServerName, err)
+	}
+	go s.Start()
+	t.Cleanup(func() {
+		s.Shutdown()
+		s.WaitForShutdown()
+	})
+	if !s.ReadyForConnections(readyTimeout) {
+		t.Fatalf("NATS server %s is not ready for connections", opts.ServerName)
+	}
+	return s
+}
+
+func newKeyPair(t testing.TB, create func() (nkeys.KeyPair, error)) nkeys.KeyPair {
+	t.Helper()
+	kp, err := create()
+	if err != nil {
+		t.Fatalf("cannot create key pair: %v", err)
+	}
+	return kp
+}
+
+func publicKey(t testing.TB, kp nkeys.KeyPair) string {
+	t.Helper()
+	pub, err := kp.PublicKey()
+	if err != nil {
+		t.Fatalf("cannot get public key: %v", err)
+	}
+	return pub
+}
+
+func encode(t testing.TB, claims natsjwt.Claims, signer nkeys.KeyPair) string {
+	t.Helper()
+	jwt, err := claims.Encode(signer)
+	if err != nil {
+		t.Fatalf("cannot encode JWT: %v", err)
+	}
+	return jwt
+}
+
+func store(t testing.TB, resolver *server.MemAccResolver, account string, jwt string) {
+	t.Helper()
+	if err := resolver.Store(account, jwt); err != nil {
+		t.Fatalf("cannot store account JWT: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package natstest
+
+import (
+	"context"
+	"testing"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+)
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+	s := Run(t, WithDomains("foo"))
+
+	client, err := nats.NewClient(s.Creds(), nil)
+	assert.Nil(err)
+	defer client.Disconnect()
+	assert.Equal(s.UserPublicKey, client.Connection().UserPublicKey)
+	assert.Equal(s.AccountPublicKey, client.Connection().AccountPublicKey)
+
+	for _, domain := range []string{"", "foo"} {
+		assert.Nil(client.CreateStream(context.Background(), domain, &natsgo.StreamConfig{Name: "orders", Subjects: []string{"orders.>"}}))
+		info, err := client.StreamInfo(context.Background(), domain, "orders")
+		assert.Nil(err)
+		assert.NotNil(info)
+	}
+
+	info, err := s.JetStream(t, "foo").StreamInfo("orders")
+	assert.Nil(err)
+	assert.Equal("orders", info.Config.Name)
+}
@@ -0,0 +1,135 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	objectStoreStreamPrefix = "OBJ_"
+)
+
+// ObjectStoreBucketName returns the name of an object store bucket that may be
+// given as the name of the stream backing it
+func ObjectStoreBucketName(name string) string {
+	return strings.TrimPrefix(name, objectStoreStreamPrefix)
+}
+
+// ObjectStoreStreamName returns the name of the stream backing an object store bucket
+func ObjectStoreStreamName(bucket string) string {
+	return objectStoreStreamPrefix + ObjectStoreBucketName(bucket)
+}
+
+// ObjectStoreInfo returns the info of the stream backing an object store bucket for a given domain
+func ObjectStoreInfo(ctx context.Context, c *Client, domain string, bucket string) (*nats.StreamInfo, error) {
+	return c.StreamInfo(ctx, domain, ObjectStoreStreamName(bucket))
+}
+
+// ObjectStoreConfigFromStream returns the object store configuration of a bucket from its backing stream configuration
+func ObjectStoreConfigFromStream(bucket string, config *nats.StreamConfig) *nats.ObjectStoreConfig {
+	return &nats.ObjectStoreConfig{
+		Bucket:      ObjectStoreBucketName(bucket),
+		Description: config.Description,
+		TTL:         config.MaxAge,
+		MaxBytes:    config.MaxBytes,
+		Storage:     config.Storage,
+		Replicas:    config.Replicas,
+		Placement:   config.Placement,
+	}
+}
+
+// CreateObjectStore creates a new jetstream object store bucket with a given configuration for a given domain
+func (c *Client) CreateObjectStore(ctx context.Context, domain string, config *nats.ObjectStoreConfig) (err error) {
+	defer observeRequest(opObjectCreate, time.Now(), &err)
+
+	js, err := c.jetStream(domain)
+	if err != nil {
+		return err
+	}
+	cfg, err := convert[jetstream.ObjectStoreConfig](config)
+	if err != nil {
+		return err
+	}
+	cfg.Bucket = ObjectStoreBucketName(config.Bucket)
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	_, err = js.CreateObjectStore(ctx, *cfg)
+	return err
+}
+
+// UpdateObjectStore updates a jetstream object store bucket with a given configuration for a given domain.
+// The bucket is updated by updating the stream backing the bucket.
+func (c *Client) UpdateObjectStore(ctx context.Context, domain string, config *nats.ObjectStoreConfig) (err error) {
+	defer observeRequest(opObjectUpdate, time.Now(), &err)
+
+	js, err := c.jetStream(domain)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	s, err := js.Stream(ctx, ObjectStoreStreamName(config.Bucket))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrStreamNotFound) {
+			return nats.ErrStreamNotFound
+		}
+		return err
+	}
+	current, err := convert[nats.StreamConfig](s.CachedInfo().Config)
+	if err != nil {
+		return err
+	}
+
+	applyObjectStoreConfig(config, current)
+	cfg, err := streamConfig(current)
+	if err != nil {
+		return err
+	}
+
+	_, err = js.UpdateStream(ctx, *cfg)
+	return err
+}
+
+// DeleteObjectStore deletes a jetstream object store bucket with a given name for a given domain
+func (c *Client) DeleteObjectStore(ctx context.Context, domain string, bucket string) (err error) {
+	defer observeRequest(opObjectDelete, time.Now(), &err)
+
+	js, err := c.jetStream(domain)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	return js.DeleteObjectStore(ctx, ObjectStoreBucketName(bucket))
+}
+
+// applyObjectStoreConfig applies the object store configuration to the backing
+// stream configuration the same way nats.go does when creating a bucket.
+func applyObjectStoreConfig(in *nats.ObjectStoreConfig, out *nats.StreamConfig) {
+	replicas := in.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+	maxBytes := in.MaxBytes
+	if maxBytes == 0 {
+		maxBytes = -1
+	}
+
+	out.Description = in.Description
+	out.MaxAge = in.TTL
+	out.MaxBytes = maxBytes
+	out.Storage = in.Storage
+	out.Replicas = replicas
+	out.Placement = in.Placement
+}
@@ -0,0 +1,30 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectStoreBucketName(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("files", ObjectStoreBucketName("files"))
+	assert.Equal("files", ObjectStoreBucketName("OBJ_files"))
+	assert.Equal("OBJ_files", ObjectStoreStreamName("files"))
+	assert.Equal("OBJ_files", ObjectStoreStreamName("OBJ_files"))
+	assert.Equal("files", ObjectStoreConfigFromStream("OBJ_files", &nats.StreamConfig{}).Bucket)
+}
+
+func TestDeleteObjectStoreStreamName(t *testing.T) {
+	assert := assert.New(t)
+	c := runJetStream(t)
+
+	ctx := context.Background()
+	assert.Nil(c.CreateObjectStore(ctx, "", &nats.ObjectStoreConfig{Bucket: "files"}))
+	assert.Nil(c.DeleteObjectStore(ctx, "", "OBJ_files"))
+	info, err := ObjectStoreInfo(ctx, c, "", "files")
+	assert.Nil(err)
+	assert.Nil(info)
+}
@@ -0,0 +1,169 @@
+package nats
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+var (
+	ErrTLSCA            = errors.New("CA bundle does not contain a valid PEM encoded certificate")
+	ErrTLSClientKeyPair = errors.New("client certificate and client key must be set together")
+)
+
+// ConnectOptions are the settings of a ProviderConfig used to connect to a
+// NATS server besides the credentials.
+type ConnectOptions struct {
+	// Auth is the authentication method. Defaults to AuthUserJWT if empty.
+	Auth string `json:"auth,omitempty"`
+	// TLS is the TLS configuration of the connection. The connection does not
+	// require TLS if nil.
+	TLS *TLSConfig `json:"tls,omitempty"`
+	// RequestTimeout is the timeout of a single JetStream API request.
+	// Defaults to apiTimeout if zero.
+	RequestTimeout time.Duration `json:"requestTimeout,omitempty"`
+	// APIPrefix is the JetStream API prefix used for requests without a
+	// domain. Defaults to $JS.API if empty.
+	APIPrefix string `json:"apiPrefix,omitempty"`
+}
+
+// TLSConfig is the TLS configuration of a connection to a NATS server.
+type TLSConfig struct {
+	// CA is the PEM encoded CA bundle used to verify the server certificate.
+	CA []byte `json:"ca,omitempty"`
+	// ClientCert is the PEM encoded client certificate used for mutual TLS.
+	ClientCert []byte `json:"clientCert,omitempty"`
+	// ClientKey is the PEM encoded client key used for mutual TLS.
+	ClientKey []byte `json:"clientKey,omitempty"`
+	// ServerName overrides the server name used to verify the server certificate.
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify disables the verification of the server certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// GetConnectOptions returns the connect options of a ProviderConfig and reads
+// the Secrets they reference.
+func GetConnectOptions(ctx context.Context, kube client.Client, pc *apisv1alpha1.ProviderConfig) (*ConnectOptions, error) {
+	opts := &ConnectOptions{
+		Auth:      pc.Spec.Credentials.Auth,
+		APIPrefix: pc.Spec.APIPrefix,
+	}
+	if pc.Spec.RequestTimeout != nil {
+		opts.RequestTimeout = pc.Spec.RequestTimeout.Duration
+	}
+
+	if t := pc.Spec.TLS; t != nil {
+		tlsConfig := &TLSConfig{
+			ServerName:         t.ServerName,
+			InsecureSkipVerify: t.InsecureSkipVerify,
+		}
+		var err error
+		if tlsConfig.CA, err = extractSecretKey(ctx, kube, t.CASecretRef); err != nil {
+			return nil, err
+		}
+		if tlsConfig.ClientCert, err = extractSecretKey(ctx, kube, t.ClientCertSecretRef); err != nil {
+			return nil, err
+		}
+		if tlsConfig.ClientKey, err = extractSecretKey(ctx, kube, t.ClientKeySecretRef); err != nil {
+			return nil, err
+		}
+		opts.TLS = tlsConfig
+	}
+
+	return opts, nil
+}
+
+func (o *ConnectOptions) auth() string {
+	if o == nil {
+		return ""
+	}
+	return o.Auth
+}
+
+func (o *ConnectOptions) requestTimeout() time.Duration {
+	if o == nil || o.RequestTimeout <= 0 {
+		return apiTimeout
+	}
+	return o.RequestTimeout
+}
+
+func (o *ConnectOptions) apiPrefix() string {
+	if o == nil {
+		return ""
+	}
+	return o.APIPrefix
+}
+
+// natsOptions returns the NATS options of the connect options.
+func (o *ConnectOptions) natsOptions() ([]nats.Option, error) {
+	opts := []nats.Option{}
+	if o == nil {
+		return opts, nil
+	}
+
+	if o.TLS != nil {
+		tlsConfig, err := o.TLS.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, nats.Secure(tlsConfig))
+	}
+
+	return opts, nil
+}
+
+func (t *TLSConfig) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify, // #nosec G402 -- explicitly requested in the ProviderConfig
+	}
+
+	if len(t.CA) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(t.CA) {
+			return nil, ErrTLSCA
+		}
+		config.RootCAs = pool
+	}
+
+	if len(t.ClientCert) > 0 || len(t.ClientKey) > 0 {
+		if len(t.ClientCert) == 0 || len(t.ClientKey) == 0 {
+			return nil, ErrTLSClientKeyPair
+		}
+		cert, err := tls.X509KeyPair(t.ClientCert, t.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// extractSecretKey returns the value of a Secret key. It returns nil if no
+// Secret key is referenced.
+func extractSecretKey(ctx context.Context, kube client.Client, ref *xpv1.SecretKeySelector) ([]byte, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	data, err := resource.ExtractSecret(ctx, kube, xpv1.CommonCredentialSelectors{SecretRef: ref})
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("secret %s/%s does not contain key %s", ref.Namespace, ref.Name, ref.Key)
+	}
+	return data, nil
+}
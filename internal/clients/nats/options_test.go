@@ -0,0 +1,241 @@
+package nats
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+// newCertificate returns a PEM encoded self-signed certificate and its key.
+func newCertificate(t *testing.T, name string) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("cannot create certificate: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("cannot marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+// getSecrets returns a kube client that gets the supplied Secrets by name.
+func getSecrets(secrets ...*corev1.Secret) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			for _, s := range secrets {
+				if s.Name == key.Name && s.Namespace == key.Namespace {
+					s.DeepCopyInto(obj.(*corev1.Secret))
+					return nil
+				}
+			}
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+		},
+	}
+}
+
+func secretKey(name, key string) *xpv1.SecretKeySelector {
+	return &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: name, Namespace: "crossplane-system"},
+		Key:             key,
+	}
+}
+
+func TestGetConnectOptions(t *testing.T) {
+	ca, _ := newCertificate(t, "ca")
+	cert, key := newCertificate(t, "client")
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "nats-tls", Namespace: "crossplane-system"},
+		Data:       map[string][]byte{"ca.crt": ca, "tls.crt": cert, "tls.key": key},
+	}
+
+	tests := []struct {
+		name     string
+		spec     apisv1alpha1.ProviderConfigSpec
+		want     *ConnectOptions
+		notFound bool
+		err      bool
+	}{
+		{
+			name: "without TLS",
+			spec: apisv1alpha1.ProviderConfigSpec{
+				Credentials:    apisv1alpha1.ProviderCredentials{Auth: AuthToken},
+				RequestTimeout: &metav1.Duration{Duration: time.Minute},
+				APIPrefix:      "$JS.hub.API",
+			},
+			want: &ConnectOptions{Auth: AuthToken, RequestTimeout: time.Minute, APIPrefix: "$JS.hub.API"},
+		},
+		{
+			name: "CA and client certificate",
+			spec: apisv1alpha1.ProviderConfigSpec{
+				TLS: &apisv1alpha1.ProviderTLS{
+					CASecretRef:         secretKey("nats-tls", "ca.crt"),
+					ClientCertSecretRef: secretKey("nats-tls", "tls.crt"),
+					ClientKeySecretRef:  secretKey("nats-tls", "tls.key"),
+					ServerName:          "nats.example.com",
+				},
+			},
+			want: &ConnectOptions{TLS: &TLSConfig{CA: ca, ClientCert: cert, ClientKey: key, ServerName: "nats.example.com"}},
+		},
+		{
+			name: "insecure",
+			spec: apisv1alpha1.ProviderConfigSpec{
+				TLS: &apisv1alpha1.ProviderTLS{InsecureSkipVerify: true},
+			},
+			want: &ConnectOptions{TLS: &TLSConfig{InsecureSkipVerify: true}},
+		},
+		{
+			name: "missing Secret",
+			spec: apisv1alpha1.ProviderConfigSpec{
+				TLS: &apisv1alpha1.ProviderTLS{CASecretRef: secretKey("missing", "ca.crt")},
+			},
+			notFound: true,
+		},
+		{
+			name: "missing Secret key",
+			spec: apisv1alpha1.ProviderConfigSpec{
+				TLS: &apisv1alpha1.ProviderTLS{ClientKeySecretRef: secretKey("nats-tls", "missing.key")},
+			},
+			err: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &apisv1alpha1.ProviderConfig{Spec: tt.spec}
+			got, err := GetConnectOptions(context.Background(), getSecrets(tlsSecret), pc)
+			switch {
+			case tt.notFound:
+				assert.True(t, kerrors.IsNotFound(err), err)
+			case tt.err:
+				assert.Error(t, err)
+			default:
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNatsOptions(t *testing.T) {
+	ca, _ := newCertificate(t, "ca")
+	cert, key := newCertificate(t, "client")
+	_, otherKey := newCertificate(t, "other")
+
+	tests := []struct {
+		name    string
+		opts    *ConnectOptions
+		options int
+		err     error
+		check   func(*testing.T, *TLSConfig)
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "without TLS",
+			opts: &ConnectOptions{Auth: AuthNone},
+		},
+		{
+			name:    "CA",
+			opts:    &ConnectOptions{TLS: &TLSConfig{CA: ca, ServerName: "nats.example.com"}},
+			options: 1,
+			check: func(t *testing.T, c *TLSConfig) {
+				config, err := c.tlsConfig()
+				assert.Nil(t, err)
+				assert.NotNil(t, config.RootCAs)
+				assert.Equal(t, "nats.example.com", config.ServerName)
+				assert.False(t, config.InsecureSkipVerify)
+			},
+		},
+		{
+			name:    "client certificate",
+			opts:    &ConnectOptions{TLS: &TLSConfig{ClientCert: cert, ClientKey: key}},
+			options: 1,
+			check: func(t *testing.T, c *TLSConfig) {
+				config, err := c.tlsConfig()
+				assert.Nil(t, err)
+				assert.Len(t, config.Certificates, 1)
+				assert.Nil(t, config.RootCAs)
+			},
+		},
+		{
+			name:    "insecure",
+			opts:    &ConnectOptions{TLS: &TLSConfig{InsecureSkipVerify: true}},
+			options: 1,
+			check: func(t *testing.T, c *TLSConfig) {
+				config, err := c.tlsConfig()
+				assert.Nil(t, err)
+				assert.True(t, config.InsecureSkipVerify)
+			},
+		},
+		{
+			name: "invalid CA",
+			opts: &ConnectOptions{TLS: &TLSConfig{CA: []byte("not a certificate")}},
+			err:  ErrTLSCA,
+		},
+		{
+			name: "client certificate without key",
+			opts: &ConnectOptions{TLS: &TLSConfig{ClientCert: cert}},
+			err:  ErrTLSClientKeyPair,
+		},
+		{
+			name: "client key without certificate",
+			opts: &ConnectOptions{TLS: &TLSConfig{ClientKey: key}},
+			err:  ErrTLSClientKeyPair,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.natsOptions()
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, got, tt.options)
+			if tt.check != nil {
+				tt.check(t, tt.opts.TLS)
+			}
+		})
+	}
+
+	// A client key that does not belong to the client certificate is rejected.
+	_, err := (&ConnectOptions{TLS: &TLSConfig{ClientCert: cert, ClientKey: otherKey}}).natsOptions()
+	assert.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package nats
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	evictionReasonCredentialsRotated    = "credentials_rotated"
+	evictionReasonConnectionClosed      = "connection_closed"
+	evictionReasonProviderConfigDeleted = "provider_config_deleted"
+)
+
+var (
+	poolConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "provider_nats_pool_connections",
+		Help: "Number of NATS connections currently held by the connection pool.",
+	})
+	poolHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "provider_nats_pool_hits_total",
+		Help: "Total number of requests served by a cached NATS connection.",
+	})
+	poolMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "provider_nats_pool_misses_total",
+		Help: "Total number of requests that required a new NATS connection.",
+	})
+	poolEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "provider_nats_pool_evictions_total",
+		Help: "Total number of NATS connections evicted from the connection pool.",
+	}, []string{"reason"})
+	poolConnectErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "provider_nats_pool_connect_errors_total",
+		Help: "Total number of failed attempts to establish a NATS connection.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(poolConnections, poolHits, poolMisses, poolEvictions, poolConnectErrors)
+}
+
+// evictionGracePeriod is the time a client replaced in the pool is kept open,
+// so that reconciles that still use it can finish. It matches the longest
+// reconcile timeout of the controllers.
+const evictionGracePeriod = 15 * time.Minute
+
+// DefaultPool is the connection pool shared by all controllers.
+var DefaultPool = NewPool()
+
+// pooledClient is the entry of a ProviderConfig. Its mutex serializes
+// connecting for the ProviderConfig only, so that a slow or unreachable
+// server does not block the reconciles of other ProviderConfigs.
+type pooledClient struct {
+	mu     sync.Mutex
+	client *Client
+	hash   string
+	// removed is set once the entry was removed from the pool, callers
+	// waiting for its mutex retry with a new entry.
+	removed bool
+}
+
+// Pool caches NATS connections per ProviderConfig so that connections are
+// reused across reconciles instead of being established for every call.
+// A cached connection is replaced when the credentials of the ProviderConfig
+// change or when the connection was closed because reconnecting failed.
+type Pool struct {
+	mu      sync.Mutex
+	clients map[string]*pooledClient
+	// gracePeriod is the time an evicted client is kept open.
+	gracePeriod time.Duration
+}
+
+// NewPool returns an empty connection pool.
+func NewPool() *Pool {
+	return &Pool{
+		clients:     map[string]*pooledClient{},
+		gracePeriod: evictionGracePeriod,
+	}
+}
+
+// entry returns the entry of a ProviderConfig, adding an empty one if needed.
+func (p *Pool) entry(providerConfig string) *pooledClient {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pc, ok := p.clients[providerConfig]
+	if !ok {
+		pc = &pooledClient{}
+		p.clients[providerConfig] = pc
+	}
+	return pc
+}
+
+// Get returns the cached client of a ProviderConfig or connects a new one
+// using the given credentials and connect options. The returned client must
+// not be disconnected by the caller.
+func (p *Pool) Get(providerConfig string, creds []byte, opts *ConnectOptions) (*Client, error) {
+	hash, err := credentialHash(creds, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		pc := p.entry(providerConfig)
+		pc.mu.Lock()
+		if pc.removed {
+			pc.mu.Unlock()
+			continue
+		}
+		client, err := p.get(pc, creds, opts, hash)
+		pc.mu.Unlock()
+		return client, err
+	}
+}
+
+// get returns the client of an entry whose mutex is held, replacing it if it
+// is outdated or closed.
+func (p *Pool) get(pc *pooledClient, creds []byte, opts *ConnectOptions, hash string) (*Client, error) {
+	if pc.client != nil {
+		switch {
+		case pc.hash != hash:
+			p.evict(pc, evictionReasonCredentialsRotated)
+		case pc.client.conn.IsClosed():
+			p.evict(pc, evictionReasonConnectionClosed)
+		default:
+			poolHits.Inc()
+			return pc.client, nil
+		}
+	}
+
+	poolMisses.Inc()
+	client, err := NewClient(creds, opts)
+	if err != nil {
+		poolConnectErrors.Inc()
+		return nil, err
+	}
+	pc.client = client
+	pc.hash = hash
+	poolConnections.Inc()
+	return client, nil
+}
+
+// Remove evicts the client of a ProviderConfig and removes its entry, e.g.
+// once the ProviderConfig was deleted.
+func (p *Pool) Remove(providerConfig string) {
+	p.mu.Lock()
+	pc, ok := p.clients[providerConfig]
+	delete(p.clients, providerConfig)
+	p.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.removed = true
+	if pc.client != nil {
+		p.evict(pc, evictionReasonProviderConfigDeleted)
+	}
+}
+
+// evict removes the client of an entry whose mutex is held. The client may
+// still be in use by other reconciles, so an open connection is closed only
+// after the grace period.
+func (p *Pool) evict(pc *pooledClient, reason string) {
+	client := pc.client
+	pc.client = nil
+	pc.hash = ""
+	if client.conn.IsClosed() {
+		client.Disconnect()
+	} else {
+		time.AfterFunc(p.gracePeriod, client.Disconnect)
+	}
+	poolConnections.Dec()
+	poolEvictions.WithLabelValues(reason).Inc()
+}
+
+// credentialHash returns a hash of everything used to establish a connection,
+// so that a change to any of it causes a reconnect.
+func credentialHash(creds []byte, opts *ConnectOptions) (string, error) {
+	o, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(creds)
+	h.Write(o)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,170 @@
+package nats
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/assert"
+)
+
+// runServer starts a NATS server without authentication and returns the
+// credentials of a ProviderConfig that connects to it.
+func runServer(t *testing.T) []byte {
+	t.Helper()
+	return startServer(t, &server.Options{Host: "127.0.0.1", Port: -1, NoLog: true, NoSigs: true})
+}
+
+// runJetStream starts a NATS server with JetStream enabled and returns a
+// client connected to it.
+func runJetStream(t *testing.T) *Client {
+	t.Helper()
+	creds := startServer(t, &server.Options{Host: "127.0.0.1", Port: -1, NoLog: true, NoSigs: true, JetStream: true, StoreDir: t.TempDir()})
+	c, err := NewPool().Get("default", creds, &ConnectOptions{Auth: AuthNone})
+	if err != nil {
+		t.Fatalf("cannot connect to NATS server: %v", err)
+	}
+	return c
+}
+
+// startServer starts a NATS server with the given options and returns the
+// credentials of a ProviderConfig that connects to it.
+func startServer(t *testing.T, opts *server.Options) []byte {
+	t.Helper()
+	s, err := server.NewServer(opts)
+	if err != nil {
+		t.Fatalf("cannot create NATS server: %v", err)
+	}
+	go s.Start()
+	t.Cleanup(s.Shutdown)
+	if !s.ReadyForConnections(5 * time.Second) {
+		t.Fatalf("NATS server is not ready for connections")
+	}
+	creds, _ := json.Marshal(Config{Address: s.ClientURL()})
+	return creds
+}
+
+// closed reports whether a client is closed within a second.
+func closed(c *Client) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if c.conn.IsClosed() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestPoolReuse(t *testing.T) {
+	assert := assert.New(t)
+	creds := runServer(t)
+	opts := &ConnectOptions{Auth: AuthNone}
+	p := NewPool()
+
+	first, err := p.Get("default", creds, opts)
+	assert.Nil(err)
+	second, err := p.Get("default", creds, opts)
+	assert.Nil(err)
+	assert.Same(first, second)
+
+	other, err := p.Get("other", creds, opts)
+	assert.Nil(err)
+	assert.NotSame(first, other)
+}
+
+func TestPoolRotation(t *testing.T) {
+	assert := assert.New(t)
+	creds := runServer(t)
+	p := NewPool()
+	p.gracePeriod = 100 * time.Millisecond
+
+	old, err := p.Get("default", creds, &ConnectOptions{Auth: AuthNone})
+	assert.Nil(err)
+	rotated, err := p.Get("default", creds, &ConnectOptions{Auth: AuthNone, RequestTimeout: time.Minute})
+	assert.Nil(err)
+	assert.NotSame(old, rotated)
+
+	// The replaced client may still be in use and is closed after the grace
+	// period only.
+	assert.False(old.conn.IsClosed())
+	assert.True(closed(old))
+	assert.False(rotated.conn.IsClosed())
+}
+
+func TestPoolConnectionClosed(t *testing.T) {
+	assert := assert.New(t)
+	creds := runServer(t)
+	opts := &ConnectOptions{Auth: AuthNone}
+	p := NewPool()
+
+	old, err := p.Get("default", creds, opts)
+	assert.Nil(err)
+	old.Disconnect()
+
+	reconnected, err := p.Get("default", creds, opts)
+	assert.Nil(err)
+	assert.NotSame(old, reconnected)
+	assert.False(reconnected.conn.IsClosed())
+}
+
+func TestPoolRemove(t *testing.T) {
+	assert := assert.New(t)
+	creds := runServer(t)
+	opts := &ConnectOptions{Auth: AuthNone}
+	p := NewPool()
+	p.gracePeriod = 100 * time.Millisecond
+
+	old, err := p.Get("default", creds, opts)
+	assert.Nil(err)
+	p.Remove("default")
+	assert.Empty(p.clients)
+	assert.True(closed(old))
+
+	p.Remove("missing")
+
+	client, err := p.Get("default", creds, opts)
+	assert.Nil(err)
+	assert.NotSame(old, client)
+}
+
+func TestPoolConnectDoesNotBlock(t *testing.T) {
+	assert := assert.New(t)
+	creds := runServer(t)
+	opts := &ConnectOptions{Auth: AuthNone}
+	p := NewPool()
+
+	// A server that accepts connections but never responds keeps connecting
+	// until the connect timeout.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+		}
+	}()
+	unresponsive, _ := json.Marshal(Config{Address: "nats://" + l.Addr().String()})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = p.Get("unresponsive", unresponsive, opts)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = p.Get("default", creds, opts)
+	assert.Nil(err)
+	select {
+	case <-done:
+		t.Errorf("p.Get(...): want connecting to an unresponsive server to still be pending")
+	default:
+	}
+	<-done
+}
@@ -1,115 +1,230 @@
 package nats
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/nats-io/jsm.go"
+	"github.com/nats-io/jsm.go/api"
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 )
 
 // StreamList returns a list of stream names for a given domain
-func StreamList(c *Client, domain string) ([]string, error) {
-	jsopts := []jsm.Option{}
-	if domain != "" {
-		jsopts = append(jsopts, jsm.WithDomain(domain))
-	}
+func (c *Client) StreamList(ctx context.Context, domain string) (names []string, err error) {
+	defer observeRequest(opStreamList, time.Now(), &err)
 
-	c.conn.NewRespInbox()
-	mgr, err := jsm.New(c.conn, jsopts...)
+	js, err := c.jetStream(domain)
 	if err != nil {
 		return nil, err
 	}
 
-	names := []string{}
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 
-	err = mgr.EachStream(&jsm.StreamNamesFilter{}, func(stream *jsm.Stream) {
-		names = append(names, stream.Name())
-	})
-	if err != nil {
+	names = []string{}
+	lister := js.StreamNames(ctx)
+	for name := range lister.Name() {
+		names = append(names, name)
+	}
+	if err := lister.Err(); err != nil {
 		return nil, err
 	}
 	return names, nil
 }
 
-// StreamInfo returns the stream info for a given stream name for a given domain
+// StreamList returns a list of stream names for a given domain
+//
+// Deprecated: Use Client.StreamList.
+func StreamList(c *Client, domain string) ([]string, error) {
+	return c.StreamList(context.Background(), domain)
+}
+
+// StreamInfo returns the stream info for a given stream name for a given domain or nil if the stream does not exist
+//
+// Deprecated: Use Client.StreamInfo.
 func StreamInfo(c *Client, domain string, stream string) (*nats.StreamInfo, error) {
-	jsOpts := []nats.JSOpt{}
-	if domain != "" {
-		jsOpts = append(jsOpts, nats.Domain(domain))
-	}
+	return c.StreamInfo(context.Background(), domain, stream)
+}
 
-	jsctx, err := c.conn.JetStream(jsOpts...)
+// StreamInfo returns the stream info for a given stream name for a given domain or nil if the stream does not exist
+func (c *Client) StreamInfo(ctx context.Context, domain string, stream string) (info *nats.StreamInfo, err error) {
+	defer observeRequest(opStreamInfo, time.Now(), &err)
+
+	js, err := c.jetStream(domain)
 	if err != nil {
 		return nil, err
 	}
 
-	info, err := jsctx.StreamInfo(stream)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	s, err := js.Stream(ctx, stream)
 	if err != nil {
-		if errors.Is(err, nats.ErrStreamNotFound) {
+		if errors.Is(err, jetstream.ErrStreamNotFound) {
 			return nil, nil
 		}
 		return nil, err
 	}
 
-	return info, nil
+	return convert[nats.StreamInfo](s.CachedInfo())
 }
 
 // CreateStream creates a new jetstream stream with a given configuration for a given domain
-func (c *Client) CreateStream(domain string, config *nats.StreamConfig) error {
-	jsOpts := []nats.JSOpt{}
-	if domain != "" {
-		jsOpts = append(jsOpts, nats.Domain(domain))
-	}
+func (c *Client) CreateStream(ctx context.Context, domain string, config *nats.StreamConfig) (err error) {
+	defer observeRequest(opStreamCreate, time.Now(), &err)
 
-	jsctx, err := c.conn.JetStream(jsOpts...)
+	js, err := c.jetStream(domain)
 	if err != nil {
 		return err
 	}
-
-	_, err = jsctx.AddStream(config)
+	cfg, err := streamConfig(config)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	_, err = js.CreateStream(ctx, *cfg)
+	return err
 }
 
 // DeleteStream deletes a jetstream stream with a given name for a given domain
-func (c *Client) DeleteStream(domain string, name string) error {
-	jsOpts := []nats.JSOpt{}
-	if domain != "" {
-		jsOpts = append(jsOpts, nats.Domain(domain))
-	}
+func (c *Client) DeleteStream(ctx context.Context, domain string, name string) (err error) {
+	defer observeRequest(opStreamDelete, time.Now(), &err)
 
-	jsctx, err := c.conn.JetStream(jsOpts...)
+	js, err := c.jetStream(domain)
 	if err != nil {
 		return err
 	}
 
-	err = jsctx.DeleteStream(name)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	return js.DeleteStream(ctx, name)
+}
+
+// UpdateStream updates a jetstream stream with a given configuration for a given domain
+func (c *Client) UpdateStream(ctx context.Context, domain string, config *nats.StreamConfig) (err error) {
+	defer observeRequest(opStreamUpdate, time.Now(), &err)
+
+	js, err := c.jetStream(domain)
+	if err != nil {
+		return err
+	}
+	cfg, err := streamConfig(config)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	_, err = js.UpdateStream(ctx, *cfg)
+	return err
 }
 
-// UpdateStream updates a jetstream stream with a given configuration for a given domain
-func (c *Client) UpdateStream(domain string, config *nats.StreamConfig) error {
-	jsOpts := []nats.JSOpt{}
-	if domain != "" {
-		jsOpts = append(jsOpts, nats.Domain(domain))
+// streamConfig converts a stream configuration into its counterpart of the
+// jetstream package. The domains of the mirror and sources are not part of the
+// JSON of the JetStream API and are copied explicitly.
+func streamConfig(config *nats.StreamConfig) (*jetstream.StreamConfig, error) {
+	cfg, err := convert[jetstream.StreamConfig](config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Mirror != nil && cfg.Mirror != nil {
+		cfg.Mirror.Domain = config.Mirror.Domain
+	}
+	for i, source := range config.Sources {
+		if source != nil && i < len(cfg.Sources) && cfg.Sources[i] != nil {
+			cfg.Sources[i].Domain = source.Domain
+		}
+	}
+	return cfg, nil
+}
+
+// manager returns the jsm.go manager of a domain, which is used for snapshots
+// as they are not supported by the jetstream package.
+func (c *Client) manager(domain string) (*jsm.Manager, error) {
+	opts := []jsm.Option{jsm.WithTimeout(c.timeout)}
+	switch {
+	case domain != "":
+		opts = append(opts, jsm.WithDomain(domain))
+	case c.apiPrefix != "":
+		opts = append(opts, jsm.WithAPIPrefix(c.apiPrefix))
+	}
+	return jsm.New(c.conn, opts...)
+}
+
+// BackupStream writes a snapshot of a jetstream stream including its consumers for a given domain into a directory
+func (c *Client) BackupStream(ctx context.Context, domain string, name string, dir string) (err error) {
+	defer observeRequest(opStreamBackup, time.Now(), &err)
+
+	mgr, err := c.manager(domain)
+	if err != nil {
+		return err
 	}
 
-	jsctx, err := c.conn.JetStream(jsOpts...)
+	stream, err := mgr.LoadStream(name)
 	if err != nil {
 		return err
 	}
 
-	_, err = jsctx.UpdateStream(config)
+	_, err = stream.SnapshotToDirectory(ctx, dir, jsm.SnapshotConsumers())
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// RestoreStream restores a snapshot written by BackupStream from a directory into a new jetstream stream for a given domain
+// and returns the number of restored messages. The stream name must match the name of the stream in the snapshot.
+func (c *Client) RestoreStream(ctx context.Context, domain string, name string, dir string) (messages uint64, err error) {
+	defer observeRequest(opStreamRestore, time.Now(), &err)
+
+	mgr, err := c.manager(domain)
+	if err != nil {
+		return 0, err
+	}
+
+	_, state, err := mgr.RestoreSnapshotFromDirectory(ctx, name, dir)
+	if err != nil {
+		return 0, err
+	}
+
+	return state.Msgs, nil
+}
+
+// PurgeStream purges messages of a jetstream stream for a given domain and returns the number of purged messages.
+// The request is sent directly as the jetstream package does not return the number of purged messages.
+func (c *Client) PurgeStream(ctx context.Context, domain string, name string, req *nats.StreamPurgeRequest) (purged uint64, err error) {
+	defer observeRequest(opStreamPurge, time.Now(), &err)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	msg, err := c.conn.RequestWithContext(ctx, fmt.Sprintf("%s.STREAM.PURGE.%s", c.APIPrefix(domain), name), body)
+	if err != nil {
+		return 0, err
+	}
+
+	resp := api.JSApiStreamPurgeResponse{}
+	if err := json.Unmarshal(msg.Data, &resp); err != nil {
+		return 0, err
+	}
+	if resp.IsError() {
+		return 0, resp.ToError()
+	}
+
+	return resp.Purged, nil
+}
@@ -21,7 +21,10 @@ import (
 	"fmt"
 	"net/http"
 
+	backupv1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/backup/v1alpha1"
 	consumerv1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/consumer/v1alpha1"
+	keyvaluev1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/keyvalue/v1alpha1"
+	objectstorev1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/objectstore/v1alpha1"
 	streamv1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/stream/v1alpha1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
@@ -30,15 +33,26 @@ import (
 
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
+	BackupV1alpha1() backupv1alpha1.BackupV1alpha1Interface
 	ConsumerV1alpha1() consumerv1alpha1.ConsumerV1alpha1Interface
+	KeyvalueV1alpha1() keyvaluev1alpha1.KeyvalueV1alpha1Interface
+	ObjectstoreV1alpha1() objectstorev1alpha1.ObjectstoreV1alpha1Interface
 	StreamV1alpha1() streamv1alpha1.StreamV1alpha1Interface
 }
 
 // Clientset contains the clients for groups.
 type Clientset struct {
 	*discovery.DiscoveryClient
-	consumerV1alpha1 *consumerv1alpha1.ConsumerV1alpha1Client
-	streamV1alpha1   *streamv1alpha1.StreamV1alpha1Client
+	backupV1alpha1      *backupv1alpha1.BackupV1alpha1Client
+	consumerV1alpha1    *consumerv1alpha1.ConsumerV1alpha1Client
+	keyvalueV1alpha1    *keyvaluev1alpha1.KeyvalueV1alpha1Client
+	objectstoreV1alpha1 *objectstorev1alpha1.ObjectstoreV1alpha1Client
+	streamV1alpha1      *streamv1alpha1.StreamV1alpha1Client
+}
+
+// BackupV1alpha1 retrieves the BackupV1alpha1Client
+func (c *Clientset) BackupV1alpha1() backupv1alpha1.BackupV1alpha1Interface {
+	return c.backupV1alpha1
 }
 
 // ConsumerV1alpha1 retrieves the ConsumerV1alpha1Client
@@ -46,6 +60,16 @@ func (c *Clientset) ConsumerV1alpha1() consumerv1alpha1.ConsumerV1alpha1Interfac
 	return c.consumerV1alpha1
 }
 
+// KeyvalueV1alpha1 retrieves the KeyvalueV1alpha1Client
+func (c *Clientset) KeyvalueV1alpha1() keyvaluev1alpha1.KeyvalueV1alpha1Interface {
+	return c.keyvalueV1alpha1
+}
+
+// ObjectstoreV1alpha1 retrieves the ObjectstoreV1alpha1Client
+func (c *Clientset) ObjectstoreV1alpha1() objectstorev1alpha1.ObjectstoreV1alpha1Interface {
+	return c.objectstoreV1alpha1
+}
+
 // StreamV1alpha1 retrieves the StreamV1alpha1Client
 func (c *Clientset) StreamV1alpha1() streamv1alpha1.StreamV1alpha1Interface {
 	return c.streamV1alpha1
@@ -95,10 +119,22 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 
 	var cs Clientset
 	var err error
+	cs.backupV1alpha1, err = backupv1alpha1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 	cs.consumerV1alpha1, err = consumerv1alpha1.NewForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
 		return nil, err
 	}
+	cs.keyvalueV1alpha1, err = keyvaluev1alpha1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	cs.objectstoreV1alpha1, err = objectstorev1alpha1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 	cs.streamV1alpha1, err = streamv1alpha1.NewForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
 		return nil, err
@@ -124,7 +160,10 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 // New creates a new Clientset for the given RESTClient.
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
+	cs.backupV1alpha1 = backupv1alpha1.New(c)
 	cs.consumerV1alpha1 = consumerv1alpha1.New(c)
+	cs.keyvalueV1alpha1 = keyvaluev1alpha1.New(c)
+	cs.objectstoreV1alpha1 = objectstorev1alpha1.New(c)
 	cs.streamV1alpha1 = streamv1alpha1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
@@ -19,8 +19,14 @@ package fake
 
 import (
 	clientset "github.com/edgefarm/provider-nats/internal/clientset/provider"
+	backupv1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/backup/v1alpha1"
+	fakebackupv1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/backup/v1alpha1/fake"
 	consumerv1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/consumer/v1alpha1"
 	fakeconsumerv1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/consumer/v1alpha1/fake"
+	keyvaluev1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/keyvalue/v1alpha1"
+	fakekeyvaluev1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/keyvalue/v1alpha1/fake"
+	objectstorev1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/objectstore/v1alpha1"
+	fakeobjectstorev1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/objectstore/v1alpha1/fake"
 	streamv1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/stream/v1alpha1"
 	fakestreamv1alpha1 "github.com/edgefarm/provider-nats/internal/clientset/provider/typed/stream/v1alpha1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -80,11 +86,26 @@ var (
 	_ testing.FakeClient  = &Clientset{}
 )
 
+// BackupV1alpha1 retrieves the BackupV1alpha1Client
+func (c *Clientset) BackupV1alpha1() backupv1alpha1.BackupV1alpha1Interface {
+	return &fakebackupv1alpha1.FakeBackupV1alpha1{Fake: &c.Fake}
+}
+
 // ConsumerV1alpha1 retrieves the ConsumerV1alpha1Client
 func (c *Clientset) ConsumerV1alpha1() consumerv1alpha1.ConsumerV1alpha1Interface {
 	return &fakeconsumerv1alpha1.FakeConsumerV1alpha1{Fake: &c.Fake}
 }
 
+// KeyvalueV1alpha1 retrieves the KeyvalueV1alpha1Client
+func (c *Clientset) KeyvalueV1alpha1() keyvaluev1alpha1.KeyvalueV1alpha1Interface {
+	return &fakekeyvaluev1alpha1.FakeKeyvalueV1alpha1{Fake: &c.Fake}
+}
+
+// ObjectstoreV1alpha1 retrieves the ObjectstoreV1alpha1Client
+func (c *Clientset) ObjectstoreV1alpha1() objectstorev1alpha1.ObjectstoreV1alpha1Interface {
+	return &fakeobjectstorev1alpha1.FakeObjectstoreV1alpha1{Fake: &c.Fake}
+}
+
 // StreamV1alpha1 retrieves the StreamV1alpha1Client
 func (c *Clientset) StreamV1alpha1() streamv1alpha1.StreamV1alpha1Interface {
 	return &fakestreamv1alpha1.FakeStreamV1alpha1{Fake: &c.Fake}
@@ -18,7 +18,10 @@ limitations under the License.
 package fake
 
 import (
+	backupv1alpha1 "github.com/edgefarm/provider-nats/apis/backup/v1alpha1"
 	consumerv1alpha1 "github.com/edgefarm/provider-nats/apis/consumer/v1alpha1"
+	keyvaluev1alpha1 "github.com/edgefarm/provider-nats/apis/keyvalue/v1alpha1"
+	objectstorev1alpha1 "github.com/edgefarm/provider-nats/apis/objectstore/v1alpha1"
 	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -31,7 +34,10 @@ var scheme = runtime.NewScheme()
 var codecs = serializer.NewCodecFactory(scheme)
 
 var localSchemeBuilder = runtime.SchemeBuilder{
+	backupv1alpha1.AddToScheme,
 	consumerv1alpha1.AddToScheme,
+	keyvaluev1alpha1.AddToScheme,
+	objectstorev1alpha1.AddToScheme,
 	streamv1alpha1.AddToScheme,
 }
 
@@ -17,7 +17,12 @@ limitations under the License.
 package config
 
 import (
+	"context"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
@@ -27,6 +32,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
 )
 
 // Setup adds a controller that reconciles ProviderConfigs by accounting for
@@ -39,9 +45,13 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		UsageList: v1alpha1.ProviderConfigUsageListGroupVersionKind,
 	}
 
-	r := providerconfig.NewReconciler(mgr, of,
-		providerconfig.WithLogger(o.Logger.WithValues("controller", name)),
-		providerconfig.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+	r := &poolReleaser{
+		kube: mgr.GetClient(),
+		pool: nats.DefaultPool,
+		wrapped: providerconfig.NewReconciler(mgr, of,
+			providerconfig.WithLogger(o.Logger.WithValues("controller", name)),
+			providerconfig.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))),
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -50,3 +60,19 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Watches(&source.Kind{Type: &v1alpha1.ProviderConfigUsage{}}, &resource.EnqueueRequestForProviderConfig{}).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
+
+// A poolReleaser removes the pooled connection of a ProviderConfig once the
+// ProviderConfig is gone, before handing the request to the wrapped reconciler.
+type poolReleaser struct {
+	kube    client.Client
+	pool    *nats.Pool
+	wrapped reconcile.Reconciler
+}
+
+func (r *poolReleaser) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	err := r.kube.Get(ctx, req.NamespacedName, &v1alpha1.ProviderConfig{})
+	if kerrors.IsNotFound(err) {
+		r.pool.Remove(req.Name)
+	}
+	return r.wrapped.Reconcile(ctx, req)
+}
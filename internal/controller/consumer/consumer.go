@@ -17,12 +17,12 @@ limitations under the License.
 package consumer
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +32,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -39,16 +40,34 @@ import (
 
 	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1"
 	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1/consumer"
+	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
 	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
 	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
 	"github.com/edgefarm/provider-nats/internal/controller/features"
+	"github.com/edgefarm/provider-nats/internal/health"
 )
 
 const (
-	errNotConsumer  = "managed resource is not a Consumer custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errGetCreds     = "cannot get credentials"
+	errNotConsumer     = "managed resource is not a Consumer custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errGetConnectOpts  = "cannot get connect options"
+	errGetStream       = "cannot get referenced Stream"
+	errStreamNotReady  = "referenced Stream %s is not ready"
+	errUpdateFields    = "cannot update fields %s"
+	errDrift           = "external resource differs from desired state in fields %s"
+	errDriftUpdated    = "external resource differed from desired state in fields %s and was updated"
+	errImmutable       = "cannot change immutable fields %s with update policy Reject, set spec.forProvider.updatePolicy to Recreate to recreate the consumer"
+	errRecreate        = "cannot recreate consumer"
+	errRestoreFailed   = "cannot create consumer: %v, cannot create previous consumer: %v"
+	errObserveOnly     = "consumer does not exist and is not created with management policy ObserveOnly"
+	errRecreatePolicy  = "cannot recreate consumer to change immutable fields %s with management policy %s"
+	errDomainAPIPrefix = "cannot set both spec.forProvider.domain and spec.forProvider.apiPrefix"
+
+	reasonDriftDetected event.Reason = "DriftDetected"
+	reasonRecreated     event.Reason = "Recreated"
+	reasonRestored      event.Reason = "RestoredPrevious"
 )
 
 // Setup adds a controller that reconciles Consumer managed resources.
@@ -60,17 +79,20 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	connector := &connector{
-		kube:   mgr.GetClient(),
-		usage:  resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-		logger: o.Logger,
+		kube:      mgr.GetClient(),
+		usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+		logger:    o.Logger,
+		recorder:  recorder,
+		newClient: nats.DefaultPool.JetStream,
 	}
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.ConsumerGroupVersionKind),
 		managed.WithExternalConnecter(connector),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -83,9 +105,11 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube   client.Client
-	usage  resource.Tracker
-	logger logging.Logger
+	kube      client.Client
+	usage     resource.Tracker
+	logger    logging.Logger
+	recorder  event.Recorder
+	newClient nats.JetStreamFactory
 }
 
 // Connect typically produces an ExternalClient by:
@@ -114,9 +138,19 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
+	opts, err := nats.GetConnectOptions(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetConnectOpts)
+	}
+
 	e := &external{
-		creds: creds,
-		log:   c.logger,
+		kube:           c.kube,
+		creds:          creds,
+		log:            c.logger,
+		recorder:       c.recorder,
+		newClient:      c.newClient,
+		providerConfig: cr.GetProviderConfigReference().Name,
+		opts:           opts,
 	}
 
 	return e, nil
@@ -125,8 +159,17 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	creds []byte
-	log   logging.Logger
+	kube           client.Client
+	creds          []byte
+	newClient      nats.JetStreamFactory
+	providerConfig string
+	opts           *nats.ConnectOptions
+	log            logging.Logger
+	recorder       event.Recorder
+	// diff holds the fields that differ from the desired state as found by Observe.
+	diff []string
+	// immutable holds the fields of diff that cannot be changed without recreating the consumer.
+	immutable []string
 }
 
 const (
@@ -143,6 +186,19 @@ func getExternalName(r *v1alpha1.Consumer) (string, error) {
 	return "", fmt.Errorf("external name annotation not found for stream %s", r.GetName())
 }
 
+// client returns the JetStream client of the consumer, which manages the consumer
+// through its API prefix if set.
+func (c *external) client(r *v1alpha1.Consumer) (nats.JetStream, error) {
+	if r.Spec.ForProvider.Domain != "" && r.Spec.ForProvider.APIPrefix != "" {
+		return nil, errors.New(errDomainAPIPrefix)
+	}
+	client, err := c.newClient(c.providerConfig, c.creds, c.opts)
+	if err != nil {
+		return nil, err
+	}
+	return client.WithAPIPrefix(r.Spec.ForProvider.APIPrefix), nil
+}
+
 func (c *external) setStatus(domain string, stream string, r *v1alpha1.Consumer, data *natsgo.ConsumerInfo) {
 	r.Status.AtProvider.State.Domain = domain
 	r.Status.AtProvider.State.Stream = stream
@@ -193,20 +249,61 @@ func (c *external) setStatus(domain string, stream string, r *v1alpha1.Consumer,
 			}
 		}
 	}
+
+	limits := r.Spec.ForProvider.Health
+	var maxReplicaLag *uint64
+	if limits != nil {
+		maxReplicaLag = limits.MaxReplicaLag
+	}
+	r.SetConditions(health.ClusterConditions(data.Cluster, maxReplicaLag)...)
+	r.SetConditions(health.BacklogCondition(data, limits))
+}
+
+// Keys of the connection details published for a consumer.
+const (
+	connectionKeyConsumer       = "consumer"
+	connectionKeyStream         = "stream"
+	connectionKeyDomain         = "domain"
+	connectionKeyAddress        = "address"
+	connectionKeyDeliverSubject = "deliverSubject"
+	connectionKeyDeliverGroup   = "deliverGroup"
+	connectionKeyPullSubject    = "pullSubject"
+)
+
+// connectionDetails returns everything a client needs to bind to the consumer.
+// Push consumers publish their deliver subject and group, pull consumers the
+// subject to request messages from.
+func connectionDetails(client nats.JetStream, domain string, stream string, name string, config *natsgo.ConsumerConfig) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		connectionKeyConsumer: []byte(name),
+		connectionKeyStream:   []byte(stream),
+		connectionKeyDomain:   []byte(domain),
+		connectionKeyAddress:  []byte(client.Connection().Address),
+	}
+	if config.DeliverSubject != "" {
+		cd[connectionKeyDeliverSubject] = []byte(config.DeliverSubject)
+		cd[connectionKeyDeliverGroup] = []byte(config.DeliverGroup)
+	} else {
+		cd[connectionKeyPullSubject] = []byte(nats.PullSubject(client.APIPrefix(domain), stream, name))
+	}
+	return cd
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	client, err := nats.NewClient(c.creds)
-	if err != nil {
-		return managed.ExternalObservation{}, err
-	}
-	defer func() {
-		client.Disconnect()
-	}()
 	r, ok := mg.(*v1alpha1.Consumer)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotConsumer)
 	}
+	client, err := c.client(r)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	policy := r.Spec.ManagementPolicy
+	if meta.WasDeleted(r) && !policy.ShouldDelete() {
+		// Report the consumer as gone so that the managed resource is finalized
+		// without deleting the consumer.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
 	externalName, err := getExternalName(r)
 	if err != nil {
 		return managed.ExternalObservation{}, err
@@ -215,7 +312,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	domain := r.Spec.ForProvider.Domain
 	stream := r.Spec.ForProvider.Stream
 
-	data, err := nats.ConsumerInfo(client, domain, externalName, stream)
+	data, err := client.ConsumerInfo(ctx, domain, stream, externalName)
 	if err != nil {
 		r.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
 		return managed.ExternalObservation{}, err
@@ -223,11 +320,32 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	if data == nil {
 		r.SetConditions(xpv1.Unavailable())
+		if !policy.ShouldCreate() {
+			return managed.ExternalObservation{}, errors.New(errObserveOnly)
+		}
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
+	lateInitialized, err := consumer.LateInitialize(&r.Spec.ForProvider.Config, &data.Config)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if !policy.ShouldUpdate() {
+		// The consumer is never changed, so it is reported as up to date
+		// regardless of the desired configuration.
+		c.setStatus(domain, stream, r, data)
+		r.SetConditions(xpv1.Available())
+		return managed.ExternalObservation{
+			ResourceExists:          true,
+			ResourceUpToDate:        true,
+			ResourceLateInitialized: lateInitialized,
+			ConnectionDetails:       connectionDetails(client, domain, stream, externalName, &data.Config),
+		}, nil
+	}
+
 	customConfig := r.Spec.ForProvider.Config
 	converted, err := consumer.ConfigV1Alpha1ToNats(externalName, &customConfig)
 	if err != nil {
@@ -238,27 +356,21 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	converted.Name = externalName
 
-	oriJson, err := json.Marshal(data.Config)
-	if err != nil {
-		return managed.ExternalObservation{}, err
-	}
-	convertedJson, err := json.Marshal(converted)
-	if err != nil {
-		return managed.ExternalObservation{}, err
-	}
-
-	if !bytes.Equal(oriJson, convertedJson) {
-		return managed.ExternalObservation{
-			ResourceExists:    true,
-			ResourceUpToDate:  false,
-			ConnectionDetails: managed.ConnectionDetails{},
-		}, nil
-	}
+	c.diff = consumer.DiffNatsConfig(converted, &data.Config)
+	c.immutable = consumer.ImmutableDiff(converted, &data.Config)
 
 	c.setStatus(domain, stream, r, data)
 
 	r.SetConditions(xpv1.Available())
 
+	// Drift is reported through the diff of the observation and an event. The
+	// reconciler sets the Synced condition from the result of the following
+	// update, which therefore reports the fields as well.
+	fields := strings.Join(c.diff, ", ")
+	if len(c.diff) > 0 {
+		c.recorder.Event(r, event.Normal(reasonDriftDetected, fmt.Sprintf(errDrift, fields)))
+	}
+
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
 		// the managed resource reconciler know that it needs to call Create to
@@ -268,26 +380,52 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
-		ResourceUpToDate: true,
+		ResourceUpToDate: len(c.diff) == 0,
+
+		// Return true when fields of the spec left unset by the user were
+		// set to the values picked by the server.
+		ResourceLateInitialized: lateInitialized,
 
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(client, domain, stream, externalName, &data.Config),
+
+		// Return the fields that differ from the desired state.
+		Diff: fields,
 	}, nil
 }
 
-func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	client, err := nats.NewClient(c.creds)
-	if err != nil {
-		return managed.ExternalCreation{}, err
+// waitForStream returns an error until the Stream referenced by the consumer is Ready.
+func (c *external) waitForStream(ctx context.Context, r *v1alpha1.Consumer) error {
+	ref := r.Spec.ForProvider.StreamRef
+	if ref == nil {
+		return nil
 	}
-	defer func() {
-		client.Disconnect()
-	}()
+	s := &streamv1alpha1.Stream{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, s); err != nil {
+		return errors.Wrap(err, errGetStream)
+	}
+	if s.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+		return errors.Errorf(errStreamNotReady, ref.Name)
+	}
+	return nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	r, ok := mg.(*v1alpha1.Consumer)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotConsumer)
 	}
+	client, err := c.client(r)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if !r.Spec.ManagementPolicy.ShouldCreate() {
+		return managed.ExternalCreation{}, errors.New(errObserveOnly)
+	}
+	if err := c.waitForStream(ctx, r); err != nil {
+		return managed.ExternalCreation{}, err
+	}
 	c.log.Info("Creating", "consumer", r)
 
 	customConfig := r.Spec.ForProvider.Config
@@ -302,7 +440,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, err
 	}
 	config.Name = externalName
-	err = client.CreateConsumer(domain, stream, config)
+	err = client.CreateConsumer(ctx, domain, stream, config)
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
@@ -310,22 +448,22 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(client, domain, stream, externalName, config),
 	}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	client, err := nats.NewClient(c.creds)
-	if err != nil {
-		return managed.ExternalUpdate{}, err
-	}
-	defer func() {
-		client.Disconnect()
-	}()
 	r, ok := mg.(*v1alpha1.Consumer)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotConsumer)
 	}
+	client, err := c.client(r)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if !r.Spec.ManagementPolicy.ShouldUpdate() {
+		return managed.ExternalUpdate{}, nil
+	}
 
 	c.log.Info("Updating", "consumer", r)
 
@@ -341,30 +479,92 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, err
 	}
 	config.Name = externalName
-	err = client.UpdateConsumer(domain, stream, config)
+
+	if len(c.immutable) > 0 {
+		err = c.recreate(ctx, client, domain, stream, r, config)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		return managed.ExternalUpdate{
+			ConnectionDetails: connectionDetails(client, domain, stream, externalName, config),
+		}, c.driftUpdated()
+	}
+
+	err = client.UpdateConsumer(ctx, domain, stream, config)
 	if err != nil {
+		if len(c.diff) > 0 {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, errUpdateFields, strings.Join(c.diff, ", "))
+		}
 		return managed.ExternalUpdate{}, err
 	}
 
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
-	}, nil
+		ConnectionDetails: connectionDetails(client, domain, stream, externalName, config),
+	}, c.driftUpdated()
 }
 
-func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
-	client, err := nats.NewClient(c.creds)
+// driftUpdated returns an error naming the fields that differed from the
+// desired state, so that the reconciler reports them in the Synced condition
+// even though the update succeeded. The following observation finds the
+// consumer up to date and reports it as synced.
+func (c *external) driftUpdated() error {
+	if len(c.diff) == 0 {
+		return nil
+	}
+	return errors.Errorf(errDriftUpdated, strings.Join(c.diff, ", "))
+}
+
+// recreate deletes and creates the consumer again if the update policy of the
+// consumer allows it, as changes to immutable fields cannot be applied by an update.
+func (c *external) recreate(ctx context.Context, client nats.JetStream, domain string, stream string, r *v1alpha1.Consumer, config *natsgo.ConsumerConfig) error {
+	fields := strings.Join(c.immutable, ", ")
+	if r.Spec.ForProvider.UpdatePolicy != consumer.UpdatePolicyRecreate {
+		return errors.Errorf(errImmutable, fields)
+	}
+	if !r.Spec.ManagementPolicy.ShouldDelete() {
+		return errors.Errorf(errRecreatePolicy, fields, r.Spec.ManagementPolicy)
+	}
+
+	// The consumer is created again from its previous configuration if the
+	// desired configuration is rejected by the server.
+	previous, err := client.ConsumerInfo(ctx, domain, stream, config.Name)
 	if err != nil {
-		return err
+		return errors.Wrap(err, errRecreate)
 	}
-	defer func() {
-		client.Disconnect()
-	}()
+
+	c.log.Info("Recreating", "consumer", r, "fields", fields)
+	if err := client.DeleteConsumer(ctx, domain, stream, config.Name); err != nil {
+		return errors.Wrap(err, errRecreate)
+	}
+	if err := client.CreateConsumer(ctx, domain, stream, config); err != nil {
+		if previous == nil {
+			return errors.Wrap(err, errRecreate)
+		}
+		if rerr := client.CreateConsumer(ctx, domain, stream, &previous.Config); rerr != nil {
+			return errors.Errorf(errRestoreFailed, err, rerr)
+		}
+		c.recorder.Event(r, event.Warning(reasonRestored, errors.Wrap(err, errRecreate)))
+		return errors.Wrap(err, errRecreate)
+	}
+	c.recorder.Event(r, event.Normal(reasonRecreated, fmt.Sprintf("Consumer recreated to change immutable fields %s", fields)))
+
+	return nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	r, ok := mg.(*v1alpha1.Consumer)
 	if !ok {
 		return errors.New(errNotConsumer)
 	}
+	client, err := c.client(r)
+	if err != nil {
+		return err
+	}
+	if !r.Spec.ManagementPolicy.ShouldDelete() {
+		return nil
+	}
 
 	c.log.Info("Deleting", "consumer", r)
 
@@ -375,5 +575,5 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return err
 	}
 
-	return client.DeleteConsumer(domain, stream, externalName)
+	return client.DeleteConsumer(ctx, domain, stream, externalName)
 }
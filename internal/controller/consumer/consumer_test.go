@@ -21,10 +21,34 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	resourcefake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1"
+	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1/consumer"
+	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+	"github.com/edgefarm/provider-nats/internal/clients/nats/fake"
+	"github.com/edgefarm/provider-nats/internal/clients/nats/natstest"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -35,9 +59,149 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-func TestObserve(t *testing.T) {
-	type fields struct {
+const (
+	testDomain = "foo"
+	testStream = "orders"
+)
+
+type consumerModifier func(*v1alpha1.Consumer)
+
+func withDomain(domain string) consumerModifier {
+	return func(r *v1alpha1.Consumer) { r.Spec.ForProvider.Domain = domain }
+}
+
+func withAPIPrefix(prefix string) consumerModifier {
+	return func(r *v1alpha1.Consumer) { r.Spec.ForProvider.APIPrefix = prefix }
+}
+
+func withPolicy(p apisv1alpha1.ManagementPolicy) consumerModifier {
+	return func(r *v1alpha1.Consumer) { r.Spec.ManagementPolicy = p }
+}
+
+func withUpdatePolicy(p string) consumerModifier {
+	return func(r *v1alpha1.Consumer) { r.Spec.ForProvider.UpdatePolicy = p }
+}
+
+func withDescription(description string) consumerModifier {
+	return func(r *v1alpha1.Consumer) { r.Spec.ForProvider.Config.Description = description }
+}
+
+func withAckPolicy(policy string) consumerModifier {
+	return func(r *v1alpha1.Consumer) { r.Spec.ForProvider.Config.AckPolicy = policy }
+}
+
+func withStreamRef(name string) consumerModifier {
+	return func(r *v1alpha1.Consumer) { r.Spec.ForProvider.StreamRef = &xpv1.Reference{Name: name} }
+}
+
+func withDeletionTimestamp() consumerModifier {
+	return func(r *v1alpha1.Consumer) {
+		now := metav1.Now()
+		r.SetDeletionTimestamp(&now)
 	}
+}
+
+func newConsumer(name string, m ...consumerModifier) *v1alpha1.Consumer {
+	r := &v1alpha1.Consumer{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.ConsumerSpec{
+			ForProvider: v1alpha1.ConsumerParameters{
+				Stream: testStream,
+				Config: consumer.ConsumerConfig{
+					DeliverPolicy: "All",
+					AckPolicy:     "Explicit",
+					ReplayPolicy:  "Instant",
+				},
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+func newExternal(s *natstest.Server) *external {
+	return &external{
+		log:            logging.NewNopLogger(),
+		creds:          s.Creds(),
+		newClient:      nats.NewPool().JetStream,
+		providerConfig: "default",
+		recorder:       event.NewNopRecorder(),
+	}
+}
+
+// getStreams returns a kube client that gets the supplied Streams by name.
+func getStreams(streams ...*streamv1alpha1.Stream) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			for _, s := range streams {
+				if s.GetName() == key.Name {
+					s.DeepCopyInto(obj.(*streamv1alpha1.Stream))
+					return nil
+				}
+			}
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "streams"}, key.Name)
+		},
+	}
+}
+
+func details(s *natstest.Server, domain string, name string) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		connectionKeyConsumer:    []byte(name),
+		connectionKeyStream:      []byte(testStream),
+		connectionKeyDomain:      []byte(domain),
+		connectionKeyAddress:     []byte(s.URL()),
+		connectionKeyPullSubject: []byte(nats.PullSubject(nats.APIPrefix(domain), testStream, name)),
+	}
+}
+
+// prefixDetails returns the connection details of a consumer managed through
+// an API prefix.
+func prefixDetails(s *natstest.Server, apiPrefix string, name string) managed.ConnectionDetails {
+	cd := details(s, "", name)
+	cd[connectionKeyPullSubject] = []byte(nats.PullSubject(apiPrefix, testStream, name))
+	return cd
+}
+
+// setup creates the stream of the consumers in every domain, independently of
+// the code under test.
+func setup(t *testing.T, s *natstest.Server, domains ...string) {
+	t.Helper()
+	for _, domain := range domains {
+		config := &natsgo.StreamConfig{Name: testStream, Subjects: []string{testStream + ".>"}}
+		if _, err := s.JetStream(t, domain).AddStream(config); err != nil {
+			t.Fatalf("cannot add stream %s: %v", testStream, err)
+		}
+	}
+}
+
+func addConsumer(t *testing.T, s *natstest.Server, domain string, config *natsgo.ConsumerConfig) {
+	t.Helper()
+	if _, err := s.JetStream(t, domain).AddConsumer(testStream, config); err != nil {
+		t.Fatalf("cannot add consumer %s: %v", config.Durable, err)
+	}
+}
+
+func consumerInfo(t *testing.T, s *natstest.Server, domain string, name string) *natsgo.ConsumerInfo {
+	t.Helper()
+	info, err := s.JetStream(t, domain).ConsumerInfo(testStream, name)
+	if errors.Is(err, natsgo.ErrConsumerNotFound) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("cannot get consumer %s: %v", name, err)
+	}
+	return info
+}
+
+func TestObserve(t *testing.T) {
+	s := natstest.Run(t, natstest.WithDomains(testDomain))
+	setup(t, s, "", testDomain)
+	addConsumer(t, s, "", &natsgo.ConsumerConfig{Durable: "uptodate", AckPolicy: natsgo.AckExplicitPolicy})
+	addConsumer(t, s, "", &natsgo.ConsumerConfig{Durable: "drift", Description: "old", AckPolicy: natsgo.AckExplicitPolicy})
+	addConsumer(t, s, testDomain, &natsgo.ConsumerConfig{Durable: "leaf", AckPolicy: natsgo.AckExplicitPolicy})
 
 	type args struct {
 		ctx context.Context
@@ -51,17 +215,116 @@ func TestObserve(t *testing.T) {
 
 	cases := map[string]struct {
 		reason string
-		fields fields
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotConsumer": {
+			reason: "An error should be returned if the managed resource is not a Consumer.",
+			args: args{
+				ctx: context.Background(),
+				mg:  nil,
+			},
+			want: want{
+				err: errors.New(errNotConsumer),
+			},
+		},
+		"DoesNotExist": {
+			reason: "A consumer that does not exist on the server should be reported as missing.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("missing"),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ObserveOnlyDoesNotExist": {
+			reason: "An error should be returned if a consumer with management policy ObserveOnly does not exist.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("missing", withPolicy(apisv1alpha1.ManagementObserveOnly)),
+			},
+			want: want{
+				err: errors.New(errObserveOnly),
+			},
+		},
+		"UpToDate": {
+			reason: "A consumer that matches the desired configuration should be up to date and late initialized.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("uptodate"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       details(s, "", "uptodate"),
+				},
+			},
+		},
+		"UpToDateInDomain": {
+			reason: "A consumer of a leaf node domain should be observed through the domain.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("leaf", withDomain(testDomain)),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       details(s, testDomain, "leaf"),
+				},
+			},
+		},
+		"Drift": {
+			reason: "A consumer that differs from the desired configuration should not be up to date.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("drift", withDescription("new")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       details(s, "", "drift"),
+					Diff:                    "Description",
+				},
+			},
+		},
+		"ObserveOnly": {
+			reason: "A consumer with management policy ObserveOnly should be up to date regardless of the desired configuration.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("drift", withDescription("new"), withPolicy(apisv1alpha1.ManagementObserveOnly)),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       details(s, "", "drift"),
+				},
+			},
+		},
+		"OrphanOnDelete": {
+			reason: "A deleted consumer with management policy OrphanOnDelete should be reported as missing.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("uptodate", withPolicy(apisv1alpha1.ManagementOrphanOnDelete), withDeletionTimestamp()),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		tc := tc
 		t.Run(name, func(t *testing.T) {
-			e := external{}
+			e := newExternal(s)
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -72,3 +335,503 @@ func TestObserve(t *testing.T) {
 		})
 	}
 }
+
+func TestObserveStatus(t *testing.T) {
+	maxPending := uint64(10)
+
+	type args struct {
+		mg       *v1alpha1.Consumer
+		consumer func(*natsgo.ConsumerInfo)
+	}
+
+	type want struct {
+		state      consumer.ConsumerObservationState
+		conditions []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Available": {
+			reason: "The state of the consumer should be reported in the status.",
+			args: args{
+				mg: newConsumer("shipping"),
+				consumer: func(info *natsgo.ConsumerInfo) {
+					info.NumPending = 5
+					info.NumAckPending = 2
+					info.Delivered = natsgo.SequenceInfo{Consumer: 7, Stream: 9}
+				},
+			},
+			want: want{
+				state: consumer.ConsumerObservationState{
+					Domain:        "",
+					Stream:        testStream,
+					Name:          "shipping",
+					Durable:       "shipping",
+					PushBound:     "no",
+					NumPending:    5,
+					NumAckPending: 2,
+					Delivered:     consumer.SequenceInfo{Consumer: 7, Stream: 9},
+				},
+				conditions: []xpv1.Condition{xpv1.Available(), apisv1alpha1.BacklogWithinLimits()},
+			},
+		},
+		"Drift": {
+			reason: "The status of a consumer that differs from the desired configuration should be refreshed without a reconcile error.",
+			args: args{
+				mg: newConsumer("shipping", withDescription("new")),
+				consumer: func(info *natsgo.ConsumerInfo) {
+					info.NumPending = 5
+				},
+			},
+			want: want{
+				state: consumer.ConsumerObservationState{
+					Domain:     "",
+					Stream:     testStream,
+					Name:       "shipping",
+					Durable:    "shipping",
+					PushBound:  "no",
+					NumPending: 5,
+				},
+				conditions: []xpv1.Condition{xpv1.Available(), {Type: xpv1.TypeSynced, Status: corev1.ConditionUnknown}},
+			},
+		},
+		"BacklogExceeded": {
+			reason: "A consumer with more pending messages than allowed should report an exceeded backlog.",
+			args: args{
+				mg: newConsumer("shipping", func(r *v1alpha1.Consumer) {
+					r.Spec.ForProvider.Health = &apisv1alpha1.ConsumerHealth{MaxPending: &maxPending}
+				}),
+				consumer: func(info *natsgo.ConsumerInfo) {
+					info.NumPending = 20
+				},
+			},
+			want: want{
+				conditions: []xpv1.Condition{xpv1.Available(), apisv1alpha1.BacklogExceeded("20 pending messages exceed 10")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			js := fake.New(nats.Connection{Address: "nats://nats:4222"})
+			if err := js.CreateStream(context.Background(), "", &natsgo.StreamConfig{Name: testStream}); err != nil {
+				t.Fatalf("cannot create stream: %v", err)
+			}
+			if err := js.CreateConsumer(context.Background(), "", testStream, &natsgo.ConsumerConfig{Durable: "shipping", AckPolicy: natsgo.AckExplicitPolicy}); err != nil {
+				t.Fatalf("cannot create consumer: %v", err)
+			}
+			tc.args.consumer(js.Consumer("", testStream, "shipping"))
+
+			e := &external{
+				log:       logging.NewNopLogger(),
+				newClient: js.Factory(),
+				recorder:  event.NewNopRecorder(),
+			}
+			if _, err := e.Observe(context.Background(), tc.args.mg); err != nil {
+				t.Errorf("\n%s\ne.Observe(...): unexpected error: %v\n", tc.reason, err)
+			}
+			if tc.want.state.Name != "" {
+				ignoreCreated := cmp.FilterPath(func(p cmp.Path) bool { return p.Last().String() == ".Created" }, cmp.Ignore())
+				if diff := cmp.Diff(tc.want.state, tc.args.mg.Status.AtProvider.State, ignoreCreated); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want state, +got state:\n%s\n", tc.reason, diff)
+				}
+			}
+			for _, c := range tc.want.conditions {
+				if diff := cmp.Diff(c, tc.args.mg.GetCondition(c.Type), test.EquateConditions()); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s := natstest.Run(t, natstest.WithDomains(testDomain))
+	setup(t, s, "", testDomain)
+
+	type args struct {
+		ctx context.Context
+		mg  *v1alpha1.Consumer
+	}
+
+	type want struct {
+		c      managed.ExternalCreation
+		err    error
+		domain string
+		exists bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Create": {
+			reason: "The consumer should be created on the server.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("shipping"),
+			},
+			want: want{
+				c:      managed.ExternalCreation{ConnectionDetails: details(s, "", "shipping")},
+				exists: true,
+			},
+		},
+		"CreateInDomain": {
+			reason: "The consumer should be created in the JetStream domain of the leaf node.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("billing", withDomain(testDomain)),
+			},
+			want: want{
+				c:      managed.ExternalCreation{ConnectionDetails: details(s, testDomain, "billing")},
+				domain: testDomain,
+				exists: true,
+			},
+		},
+		"CreateThroughAPIPrefix": {
+			reason: "The consumer should be created through the JetStream API of its API prefix.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("invoicing", withAPIPrefix(nats.APIPrefix(testDomain))),
+			},
+			want: want{
+				c:      managed.ExternalCreation{ConnectionDetails: prefixDetails(s, nats.APIPrefix(testDomain), "invoicing")},
+				domain: testDomain,
+				exists: true,
+			},
+		},
+		"DomainAndAPIPrefix": {
+			reason: "An error should be returned if both domain and API prefix are set.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("conflict", withDomain(testDomain), withAPIPrefix(nats.APIPrefix(testDomain))),
+			},
+			want: want{
+				err:    errors.New(errDomainAPIPrefix),
+				domain: testDomain,
+			},
+		},
+		"StreamReady": {
+			reason: "The consumer should be created once the referenced Stream is ready.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("packing", withStreamRef("orders-ready")),
+			},
+			want: want{
+				c:      managed.ExternalCreation{ConnectionDetails: details(s, "", "packing")},
+				exists: true,
+			},
+		},
+		"StreamNotReady": {
+			reason: "The consumer should not be created while the referenced Stream is not ready.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("pending", withStreamRef("orders-pending")),
+			},
+			want: want{
+				err: errors.Errorf(errStreamNotReady, "orders-pending"),
+			},
+		},
+		"StreamNotFound": {
+			reason: "An error should be returned if the referenced Stream does not exist.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("orphaned", withStreamRef("orders-missing")),
+			},
+			want: want{
+				err: errors.Wrap(kerrors.NewNotFound(schema.GroupResource{Resource: "streams"}, "orders-missing"), errGetStream),
+			},
+		},
+		"ObserveOnly": {
+			reason: "A consumer with management policy ObserveOnly should never be created.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("observed", withPolicy(apisv1alpha1.ManagementObserveOnly)),
+			},
+			want: want{
+				err: errors.New(errObserveOnly),
+			},
+		},
+	}
+
+	ready := &streamv1alpha1.Stream{ObjectMeta: metav1.ObjectMeta{Name: "orders-ready"}}
+	ready.SetConditions(xpv1.Available())
+	kube := getStreams(ready, &streamv1alpha1.Stream{ObjectMeta: metav1.ObjectMeta{Name: "orders-pending"}})
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := newExternal(s)
+			e.kube = kube
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			info := consumerInfo(t, s, tc.want.domain, tc.args.mg.GetName())
+			if exists := info != nil; exists != tc.want.exists {
+				t.Errorf("\n%s\ne.Create(...): want consumer exists %t, got %t\n", tc.reason, tc.want.exists, exists)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s := natstest.Run(t)
+	setup(t, s, "")
+	addConsumer(t, s, "", &natsgo.ConsumerConfig{Durable: "description", Description: "old", AckPolicy: natsgo.AckExplicitPolicy})
+	addConsumer(t, s, "", &natsgo.ConsumerConfig{Durable: "rejected", AckPolicy: natsgo.AckExplicitPolicy})
+	addConsumer(t, s, "", &natsgo.ConsumerConfig{Durable: "recreated", AckPolicy: natsgo.AckExplicitPolicy})
+
+	type args struct {
+		ctx       context.Context
+		mg        *v1alpha1.Consumer
+		diff      []string
+		immutable []string
+	}
+
+	type want struct {
+		u           managed.ExternalUpdate
+		err         error
+		description string
+		ackPolicy   natsgo.AckPolicy
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Update": {
+			reason: "Fields that differ from the desired configuration should be updated and reported.",
+			args: args{
+				ctx:  context.Background(),
+				mg:   newConsumer("description", withDescription("new")),
+				diff: []string{"Description"},
+			},
+			want: want{
+				u:           managed.ExternalUpdate{ConnectionDetails: details(s, "", "description")},
+				err:         errors.Errorf(errDriftUpdated, "Description"),
+				description: "new",
+				ackPolicy:   natsgo.AckExplicitPolicy,
+			},
+		},
+		"RejectImmutable": {
+			reason: "Changes to immutable fields should be rejected with update policy Reject.",
+			args: args{
+				ctx:       context.Background(),
+				mg:        newConsumer("rejected", withAckPolicy("None")),
+				diff:      []string{"AckPolicy"},
+				immutable: []string{"AckPolicy"},
+			},
+			want: want{
+				err:       errors.Errorf(errImmutable, "AckPolicy"),
+				ackPolicy: natsgo.AckExplicitPolicy,
+			},
+		},
+		"RecreateImmutable": {
+			reason: "Changes to immutable fields should recreate the consumer with update policy Recreate.",
+			args: args{
+				ctx:       context.Background(),
+				mg:        newConsumer("recreated", withAckPolicy("None"), withUpdatePolicy(consumer.UpdatePolicyRecreate)),
+				diff:      []string{"AckPolicy"},
+				immutable: []string{"AckPolicy"},
+			},
+			want: want{
+				u:         managed.ExternalUpdate{ConnectionDetails: details(s, "", "recreated")},
+				err:       errors.Errorf(errDriftUpdated, "AckPolicy"),
+				ackPolicy: natsgo.AckNonePolicy,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := newExternal(s)
+			e.diff = tc.args.diff
+			e.immutable = tc.args.immutable
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.u, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			info := consumerInfo(t, s, "", tc.args.mg.GetName())
+			if diff := cmp.Diff(tc.want.description, info.Config.Description); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want description, +got description:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ackPolicy, info.Config.AckPolicy); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want ack policy, +got ack policy:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRecreateRestore(t *testing.T) {
+	ctx := context.Background()
+	js := fake.New(nats.Connection{})
+	if err := js.CreateStream(ctx, "", &natsgo.StreamConfig{Name: testStream, Retention: natsgo.WorkQueuePolicy}); err != nil {
+		t.Fatalf("cannot create stream: %v", err)
+	}
+	if err := js.CreateConsumer(ctx, "", testStream, &natsgo.ConsumerConfig{Durable: "worker", Description: "jobs", AckPolicy: natsgo.AckExplicitPolicy}); err != nil {
+		t.Fatalf("cannot create consumer: %v", err)
+	}
+	e := &external{
+		log:       logging.NewNopLogger(),
+		newClient: js.Factory(),
+		recorder:  event.NewNopRecorder(),
+		diff:      []string{"AckPolicy"},
+		immutable: []string{"AckPolicy"},
+	}
+
+	// A pull consumer of a work queue stream must acknowledge its messages,
+	// so the server rejects the new configuration after the consumer was
+	// deleted.
+	_, err := e.Update(ctx, newConsumer("worker", withAckPolicy("None"), withUpdatePolicy(consumer.UpdatePolicyRecreate)))
+	if diff := cmp.Diff(errors.Wrap(fake.ErrPullRequiresAck, errRecreate), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+	}
+	info := js.Consumer("", testStream, "worker")
+	if info == nil {
+		t.Fatalf("e.Update(...): want previous consumer, got no consumer")
+	}
+	if diff := cmp.Diff(natsgo.AckExplicitPolicy, info.Config.AckPolicy); diff != "" {
+		t.Errorf("e.Update(...): -want ack policy, +got ack policy:\n%s", diff)
+	}
+	if diff := cmp.Diff("jobs", info.Config.Description); diff != "" {
+		t.Errorf("e.Update(...): -want description, +got description:\n%s", diff)
+	}
+}
+
+func TestReconcileDrift(t *testing.T) {
+	js := fake.New(nats.Connection{})
+	if err := js.CreateStream(context.Background(), "", &natsgo.StreamConfig{Name: testStream}); err != nil {
+		t.Fatalf("cannot create stream: %v", err)
+	}
+	if err := js.CreateConsumer(context.Background(), "", testStream, &natsgo.ConsumerConfig{Durable: "shipping", AckPolicy: natsgo.AckExplicitPolicy}); err != nil {
+		t.Fatalf("cannot create consumer: %v", err)
+	}
+	mg := newConsumer("shipping", withDescription("new"))
+	mg.SetFinalizers([]string{"finalizer.managedresource.crossplane.io"})
+	store := func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*v1alpha1.Consumer).DeepCopyInto(mg)
+		return nil
+	}
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			mg.DeepCopyInto(obj.(*v1alpha1.Consumer))
+			return nil
+		},
+		MockUpdate:       store,
+		MockStatusUpdate: store,
+	}
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("cannot add to scheme: %v", err)
+	}
+	r := managed.NewReconciler(&resourcefake.Manager{Client: kube, Scheme: scheme},
+		resource.ManagedKind(v1alpha1.ConsumerGroupVersionKind),
+		managed.WithExternalConnecter(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+			return &external{log: logging.NewNopLogger(), newClient: js.Factory(), recorder: event.NewNopRecorder()}, nil
+		})),
+		managed.WithConnectionPublishers(),
+	)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "shipping"}}
+
+	// The reconcile that finds the drift reports the fields in the Synced
+	// condition.
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("r.Reconcile(...): %v", err)
+	}
+	want := xpv1.ReconcileError(errors.Wrap(errors.Errorf(errDriftUpdated, "Description"), "update failed"))
+	if diff := cmp.Diff(want, mg.GetCondition(xpv1.TypeSynced), test.EquateConditions()); diff != "" {
+		t.Errorf("r.Reconcile(...): -want Synced, +got Synced:\n%s", diff)
+	}
+
+	// The following reconcile finds the consumer up to date.
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("r.Reconcile(...): %v", err)
+	}
+	if diff := cmp.Diff(xpv1.ReconcileSuccess(), mg.GetCondition(xpv1.TypeSynced), test.EquateConditions()); diff != "" {
+		t.Errorf("r.Reconcile(...): -want Synced, +got Synced:\n%s", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := natstest.Run(t, natstest.WithDomains(testDomain))
+	setup(t, s, "", testDomain)
+	addConsumer(t, s, "", &natsgo.ConsumerConfig{Durable: "shipping", AckPolicy: natsgo.AckExplicitPolicy})
+	addConsumer(t, s, "", &natsgo.ConsumerConfig{Durable: "orphaned", AckPolicy: natsgo.AckExplicitPolicy})
+	addConsumer(t, s, testDomain, &natsgo.ConsumerConfig{Durable: "billing", AckPolicy: natsgo.AckExplicitPolicy})
+
+	type args struct {
+		ctx context.Context
+		mg  *v1alpha1.Consumer
+	}
+
+	type want struct {
+		err    error
+		exists bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Delete": {
+			reason: "The consumer should be deleted from the server.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("shipping"),
+			},
+			want: want{
+				exists: false,
+			},
+		},
+		"DeleteInDomain": {
+			reason: "The consumer should be deleted from the JetStream domain of the leaf node.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("billing", withDomain(testDomain)),
+			},
+			want: want{
+				exists: false,
+			},
+		},
+		"OrphanOnDelete": {
+			reason: "A consumer with management policy OrphanOnDelete should be kept on the server.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newConsumer("orphaned", withPolicy(apisv1alpha1.ManagementOrphanOnDelete)),
+			},
+			want: want{
+				exists: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := newExternal(s)
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			info := consumerInfo(t, s, tc.args.mg.Spec.ForProvider.Domain, tc.args.mg.GetName())
+			if exists := info != nil; exists != tc.want.exists {
+				t.Errorf("\n%s\ne.Delete(...): want consumer exists %t, got %t\n", tc.reason, tc.want.exists, exists)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvalue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/edgefarm/provider-nats/apis/keyvalue/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+	"github.com/edgefarm/provider-nats/internal/clients/nats/natstest"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const testDomain = "foo"
+
+type keyValueModifier func(*v1alpha1.KeyValue)
+
+func withDomain(domain string) keyValueModifier {
+	return func(r *v1alpha1.KeyValue) { r.Spec.ForProvider.Domain = domain }
+}
+
+func withDescription(description string) keyValueModifier {
+	return func(r *v1alpha1.KeyValue) { r.Spec.ForProvider.Config.Description = description }
+}
+
+func withHistory(history int) keyValueModifier {
+	return func(r *v1alpha1.KeyValue) { r.Spec.ForProvider.Config.History = history }
+}
+
+func withMaxBytes(maxBytes int64) keyValueModifier {
+	return func(r *v1alpha1.KeyValue) { r.Spec.ForProvider.Config.MaxBytes = maxBytes }
+}
+
+// newKeyValue returns a KeyValue with the defaults of the API server applied
+// to its configuration.
+func newKeyValue(name string, m ...keyValueModifier) *v1alpha1.KeyValue {
+	r := &v1alpha1.KeyValue{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	r.Spec.ForProvider.Config.SetDefaults()
+	meta.SetExternalName(r, name)
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+func newExternal(s *natstest.Server) *external {
+	return &external{
+		log:            logging.NewNopLogger(),
+		creds:          s.Creds(),
+		pool:           nats.NewPool(),
+		providerConfig: "default",
+	}
+}
+
+// addBucket creates a bucket directly on the server, independently of the
+// code under test.
+func addBucket(t *testing.T, s *natstest.Server, domain string, config *natsgo.KeyValueConfig) {
+	t.Helper()
+	if _, err := s.JetStream(t, domain).CreateKeyValue(config); err != nil {
+		t.Fatalf("cannot add bucket %s: %v", config.Bucket, err)
+	}
+}
+
+// bucketInfo returns the info of the stream backing a bucket or nil if the
+// bucket does not exist.
+func bucketInfo(t *testing.T, s *natstest.Server, domain string, bucket string) *natsgo.StreamInfo {
+	t.Helper()
+	info, err := s.JetStream(t, domain).StreamInfo(nats.KeyValueStreamName(bucket))
+	if errors.Is(err, natsgo.ErrStreamNotFound) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("cannot get bucket %s: %v", bucket, err)
+	}
+	return info
+}
+
+func TestObserve(t *testing.T) {
+	s := natstest.Run(t)
+	addBucket(t, s, "", &natsgo.KeyValueConfig{Bucket: "uptodate"})
+	addBucket(t, s, "", &natsgo.KeyValueConfig{Bucket: "drift", Description: "old", History: 1})
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotKeyValue": {
+			reason: "An error should be returned if the managed resource is not a KeyValue.",
+			args: args{
+				ctx: context.Background(),
+				mg:  nil,
+			},
+			want: want{
+				err: errors.New(errNotKeyValue),
+			},
+		},
+		"DoesNotExist": {
+			reason: "A bucket that does not exist on the server should be reported as missing.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newKeyValue("missing"),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "A bucket that matches the desired configuration should be up to date.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newKeyValue("uptodate"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"ServerDefaults": {
+			reason: "Unset limits should not be reported as drift from the limits stored by the server.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newKeyValue("uptodate", withMaxBytes(0), withHistory(0)),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"Drift": {
+			reason: "The fields of a bucket that differ from the desired configuration should be reported.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newKeyValue("drift", withDescription("new"), withHistory(5)),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{},
+					Diff:              "Description, History",
+				},
+			},
+		},
+	}
+
+	e := newExternal(s)
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s := natstest.Run(t, natstest.WithDomains(testDomain))
+
+	type args struct {
+		ctx context.Context
+		mg  *v1alpha1.KeyValue
+	}
+
+	type want struct {
+		err     error
+		history int64
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Create": {
+			reason: "A bucket should be created with the desired configuration.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newKeyValue("created", withHistory(3)),
+			},
+			want: want{
+				history: 3,
+			},
+		},
+		"CreateInDomain": {
+			reason: "A bucket should be created in its domain.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newKeyValue("leaf", withDomain(testDomain)),
+			},
+			want: want{
+				history: 1,
+			},
+		},
+	}
+
+	e := newExternal(s)
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			info := bucketInfo(t, s, tc.args.mg.Spec.ForProvider.Domain, tc.args.mg.GetName())
+			if info == nil {
+				t.Fatalf("\n%s\ne.Create(...): bucket was not created\n", tc.reason)
+			}
+			if info.Config.MaxMsgsPerSubject != tc.want.history {
+				t.Errorf("\n%s\ne.Create(...): want history %d, got %d\n", tc.reason, tc.want.history, info.Config.MaxMsgsPerSubject)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s := natstest.Run(t)
+	addBucket(t, s, "", &natsgo.KeyValueConfig{Bucket: "updated", Description: "old"})
+
+	type args struct {
+		ctx context.Context
+		mg  *v1alpha1.KeyValue
+	}
+
+	type want struct {
+		err         error
+		description string
+		history     int64
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Update": {
+			reason: "The stream backing a bucket should be updated to the desired configuration.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newKeyValue("updated", withDescription("new"), withHistory(5)),
+			},
+			want: want{
+				description: "new",
+				history:     5,
+			},
+		},
+		"DoesNotExist": {
+			reason: "An error should be returned if the bucket does not exist.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newKeyValue("missing"),
+			},
+			want: want{
+				err: natsgo.ErrBucketNotFound,
+			},
+		},
+	}
+
+	e := newExternal(s)
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			info := bucketInfo(t, s, "", tc.args.mg.GetName())
+			if info.Config.Description != tc.want.description {
+				t.Errorf("\n%s\ne.Update(...): want description %q, got %q\n", tc.reason, tc.want.description, info.Config.Description)
+			}
+			if info.Config.MaxMsgsPerSubject != tc.want.history {
+				t.Errorf("\n%s\ne.Update(...): want history %d, got %d\n", tc.reason, tc.want.history, info.Config.MaxMsgsPerSubject)
+			}
+			o, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if err != nil || !o.ResourceUpToDate {
+				t.Errorf("\n%s\ne.Observe(...): want up to date after update, got %+v, %v\n", tc.reason, o, err)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := natstest.Run(t)
+	addBucket(t, s, "", &natsgo.KeyValueConfig{Bucket: "deleted"})
+	e := newExternal(s)
+
+	if err := e.Delete(context.Background(), newKeyValue("deleted")); err != nil {
+		t.Errorf("e.Delete(...): unexpected error: %v", err)
+	}
+	if bucketInfo(t, s, "", "deleted") != nil {
+		t.Errorf("e.Delete(...): want bucket deleted, got bucket")
+	}
+}
@@ -22,7 +22,11 @@ import (
 
 	"github.com/edgefarm/provider-nats/internal/controller/config"
 	consumer "github.com/edgefarm/provider-nats/internal/controller/consumer"
+	keyvalue "github.com/edgefarm/provider-nats/internal/controller/keyvalue"
+	objectstore "github.com/edgefarm/provider-nats/internal/controller/objectstore"
 	stream "github.com/edgefarm/provider-nats/internal/controller/stream"
+	streambackup "github.com/edgefarm/provider-nats/internal/controller/streambackup"
+	streamrestore "github.com/edgefarm/provider-nats/internal/controller/streamrestore"
 )
 
 // Setup creates all NATS controllers with the supplied logger and adds them to
@@ -32,6 +36,10 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		config.Setup,
 		stream.Setup,
 		consumer.Setup,
+		keyvalue.Setup,
+		objectstore.Setup,
+		streambackup.Setup,
+		streamrestore.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
 			return err
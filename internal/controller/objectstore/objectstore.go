@@ -0,0 +1,323 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/edgefarm/provider-nats/apis/objectstore/v1alpha1"
+	"github.com/edgefarm/provider-nats/apis/objectstore/v1alpha1/objectstore"
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+	"github.com/edgefarm/provider-nats/internal/controller/features"
+)
+
+const (
+	errNotObjectStore = "managed resource is not an ObjectStore custom resource"
+	errTrackPCUsage   = "cannot track ProviderConfig usage"
+	errGetPC          = "cannot get ProviderConfig"
+	errGetCreds       = "cannot get credentials"
+	errGetConnectOpts = "cannot get connect options"
+)
+
+// Setup adds a controller that reconciles ObjectStore managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ObjectStoreGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	connector := &connector{
+		kube:   mgr.GetClient(),
+		usage:  resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+		logger: o.Logger,
+		pool:   nats.DefaultPool,
+	}
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ObjectStoreGroupVersionKind),
+		managed.WithExternalConnecter(connector),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ObjectStore{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube   client.Client
+	usage  resource.Tracker
+	logger logging.Logger
+	pool   *nats.Pool
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ObjectStore)
+	if !ok {
+		return nil, errors.New(errNotObjectStore)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	creds, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	opts, err := nats.GetConnectOptions(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetConnectOpts)
+	}
+
+	e := &external{
+		creds:          creds,
+		log:            c.logger,
+		pool:           c.pool,
+		providerConfig: cr.GetProviderConfigReference().Name,
+		opts:           opts,
+	}
+
+	return e, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	log            logging.Logger
+	creds          []byte
+	pool           *nats.Pool
+	providerConfig string
+	opts           *nats.ConnectOptions
+}
+
+const (
+	annotationExternalName = "crossplane.io/external-name"
+)
+
+func getExternalName(r *v1alpha1.ObjectStore) (string, error) {
+	annotations := r.GetAnnotations()
+	if annotations != nil {
+		if val, ok := annotations[annotationExternalName]; ok {
+			return val, nil
+		}
+	}
+	return "", fmt.Errorf("external name annotation not found for object store bucket %s", r.GetName())
+}
+
+func (c *external) setStatus(client *nats.Client, domain string, bucket string, r *v1alpha1.ObjectStore, data *natsgo.StreamInfo) {
+	r.Status.AtProvider.Domain = domain
+	// Update connection details
+	conn := client.Connection()
+	r.Status.AtProvider.Connection.Address = conn.Address
+	r.Status.AtProvider.Connection.UserPublicKey = conn.UserPublicKey
+	r.Status.AtProvider.Connection.AccountPublicKey = conn.AccountPublicKey
+
+	// Update status information for the bucket
+	r.Status.AtProvider.State.Bucket = bucket
+	r.Status.AtProvider.State.Size = humanize.Bytes(data.State.Bytes)
+	r.Status.AtProvider.State.TTL = data.Config.MaxAge.String()
+	r.Status.AtProvider.State.Sealed = data.Config.Sealed
+	r.Status.AtProvider.State.BackingStore = "JetStream"
+
+	// Update status information for cluster information
+	if data.Cluster != nil {
+		r.Status.AtProvider.ClusterInfo.Leader = data.Cluster.Leader
+		if data.Cluster.Replicas != nil {
+			peerInfos := []*stream.PeerInfo{}
+			for _, peer := range data.Cluster.Replicas {
+				peerInfos = append(peerInfos, stream.ConvertPeerInfo(peer))
+			}
+			r.Status.AtProvider.ClusterInfo.Replicas = peerInfos
+		}
+		r.Status.AtProvider.ClusterInfo.Name = data.Cluster.Name
+	}
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	client, err := c.pool.Get(c.providerConfig, c.creds, c.opts)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	r, ok := mg.(*v1alpha1.ObjectStore)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotObjectStore)
+	}
+	externalName, err := getExternalName(r)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	domain := r.Spec.ForProvider.Domain
+
+	data, err := nats.ObjectStoreInfo(ctx, client, domain, externalName)
+	if err != nil {
+		r.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
+		return managed.ExternalObservation{}, err
+	}
+
+	if data == nil {
+		r.SetConditions(xpv1.Unavailable())
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	customConfig := r.Spec.ForProvider.Config
+	converted, err := objectstore.ConfigV1Alpha1ToNats(externalName, &customConfig)
+	if err != nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, err
+	}
+
+	diff := objectstore.DiffNatsConfig(converted, nats.ObjectStoreConfigFromStream(externalName, &data.Config))
+
+	c.setStatus(client, domain, externalName, r, data)
+
+	r.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  len(diff) == 0,
+		ConnectionDetails: managed.ConnectionDetails{},
+		Diff:              strings.Join(diff, ", "),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	client, err := c.pool.Get(c.providerConfig, c.creds, c.opts)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	r, ok := mg.(*v1alpha1.ObjectStore)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotObjectStore)
+	}
+	c.log.Info("Creating", "objectstore", r)
+
+	customConfig := r.Spec.ForProvider.Config
+	domain := r.Spec.ForProvider.Domain
+	externalName, err := getExternalName(r)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	config, err := objectstore.ConfigV1Alpha1ToNats(externalName, &customConfig)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	err = client.CreateObjectStore(ctx, domain, config)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	return managed.ExternalCreation{
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	client, err := c.pool.Get(c.providerConfig, c.creds, c.opts)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	r, ok := mg.(*v1alpha1.ObjectStore)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotObjectStore)
+	}
+	c.log.Info("Updating", "objectstore", r)
+
+	customConfig := r.Spec.ForProvider.Config
+	domain := r.Spec.ForProvider.Domain
+	externalName, err := getExternalName(r)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	config, err := objectstore.ConfigV1Alpha1ToNats(externalName, &customConfig)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	err = client.UpdateObjectStore(ctx, domain, config)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	client, err := c.pool.Get(c.providerConfig, c.creds, c.opts)
+	if err != nil {
+		return err
+	}
+	r, ok := mg.(*v1alpha1.ObjectStore)
+	if !ok {
+		return errors.New(errNotObjectStore)
+	}
+	c.log.Info("Deleting", "objectstore", r)
+	domain := r.Spec.ForProvider.Domain
+	externalName, err := getExternalName(r)
+	if err != nil {
+		return err
+	}
+
+	return client.DeleteObjectStore(ctx, domain, externalName)
+}
@@ -17,13 +17,16 @@ limitations under the License.
 package stream
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,6 +36,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -45,15 +49,54 @@ import (
 	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
 	"github.com/edgefarm/provider-nats/internal/controller/features"
 	"github.com/edgefarm/provider-nats/internal/convert"
+	"github.com/edgefarm/provider-nats/internal/health"
+	"github.com/edgefarm/provider-nats/internal/snapshot"
 )
 
 const (
-	errNotStream    = "managed resource is not a Stream custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errGetCreds     = "cannot get credentials"
+	errNotStream       = "managed resource is not a Stream custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errGetConnectOpts  = "cannot get connect options"
+	errUpdateFields    = "cannot update fields %s"
+	errDrift           = "external resource differs from desired state in fields %s"
+	errDriftUpdated    = "external resource differed from desired state in fields %s and was updated"
+	errImmutable       = "cannot change immutable fields %s with update policy Reject, set spec.forProvider.updatePolicy to Recreate or RecreateWithBackup to recreate the stream"
+	errBackup          = "cannot backup stream before recreating it"
+	errRecreate        = "cannot recreate stream"
+	errObserveOnly     = "stream does not exist and is not created with management policy ObserveOnly"
+	errRecreatePolicy  = "cannot recreate stream to change immutable fields %s with management policy %s"
+	errGetSource       = "cannot get referenced Stream"
+	errSourceNotReady  = "referenced source Stream %s is not ready"
+	errPurge           = "cannot purge stream"
+	errRecordPurge     = "cannot record purge request"
+	errPurgeUnknown    = "purge was interrupted, its result is unknown"
+	errDomainAPIPrefix = "cannot set both spec.forProvider.domain and spec.forProvider.apiPrefix"
+	errNoBackupTarget  = "spec.forProvider.backup must be set for update policy RecreateWithBackup"
+	errRestoreFailed   = "cannot create stream: %v, cannot restore stream from its snapshot: %v"
+
+	reasonDriftDetected event.Reason = "DriftDetected"
+	reasonBackedUp      event.Reason = "BackedUp"
+	reasonRecreated     event.Reason = "Recreated"
+	reasonRestored      event.Reason = "RestoredFromBackup"
+	reasonPurged        event.Reason = "Purged"
+	reasonPurgeFailed   event.Reason = "PurgeFailed"
 )
 
+// Keys of the connection details published for a stream.
+const (
+	connectionKeyStream    = "stream"
+	connectionKeyDomain    = "domain"
+	connectionKeySubjects  = "subjects"
+	connectionKeyAddress   = "address"
+	connectionKeyAPIPrefix = "apiPrefix"
+)
+
+// reconcileTimeout bounds a single reconcile, which includes taking and
+// storing the snapshot of a stream before it is recreated.
+const reconcileTimeout = 15 * time.Minute
+
 // Setup adds a controller that reconciles Stream managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1alpha1.StreamGroupKind)
@@ -63,17 +106,21 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	connector := &connector{
-		kube:   mgr.GetClient(),
-		usage:  resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-		logger: o.Logger,
+		kube:      mgr.GetClient(),
+		usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+		logger:    o.Logger,
+		recorder:  recorder,
+		newClient: nats.DefaultPool.JetStream,
 	}
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.StreamGroupVersionKind),
 		managed.WithExternalConnecter(connector),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithTimeout(reconcileTimeout),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -86,9 +133,11 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube   client.Client
-	usage  resource.Tracker
-	logger logging.Logger
+	kube      client.Client
+	usage     resource.Tracker
+	logger    logging.Logger
+	recorder  event.Recorder
+	newClient nats.JetStreamFactory
 }
 
 // Connect typically produces an ExternalClient by:
@@ -117,9 +166,19 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
+	opts, err := nats.GetConnectOptions(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetConnectOpts)
+	}
+
 	e := &external{
-		creds: creds,
-		log:   c.logger,
+		kube:           c.kube,
+		creds:          creds,
+		log:            c.logger,
+		recorder:       c.recorder,
+		newClient:      c.newClient,
+		providerConfig: cr.GetProviderConfigReference().Name,
+		opts:           opts,
 	}
 
 	return e, nil
@@ -128,8 +187,17 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	log   logging.Logger
-	creds []byte
+	kube           client.Client
+	log            logging.Logger
+	creds          []byte
+	newClient      nats.JetStreamFactory
+	providerConfig string
+	opts           *nats.ConnectOptions
+	recorder       event.Recorder
+	// diff holds the fields that differ from the desired state as found by Observe.
+	diff []string
+	// immutable holds the fields of diff that cannot be changed without recreating the stream.
+	immutable []string
 }
 
 const (
@@ -146,15 +214,30 @@ func getExternalName(r *v1alpha1.Stream) (string, error) {
 	return "", fmt.Errorf("external name annotation not found for stream %s", r.GetName())
 }
 
-func (c *external) setStatus(client *nats.Client, domain string, r *v1alpha1.Stream, data *natsgo.StreamInfo) error {
+// client returns the JetStream client of the stream, which manages the stream
+// through its API prefix if set.
+func (c *external) client(r *v1alpha1.Stream) (nats.JetStream, error) {
+	if r.Spec.ForProvider.Domain != "" && r.Spec.ForProvider.APIPrefix != "" {
+		return nil, errors.New(errDomainAPIPrefix)
+	}
+	client, err := c.newClient(c.providerConfig, c.creds, c.opts)
+	if err != nil {
+		return nil, err
+	}
+	return client.WithAPIPrefix(r.Spec.ForProvider.APIPrefix), nil
+}
+
+func (c *external) setStatus(client nats.JetStream, domain string, r *v1alpha1.Stream, data *natsgo.StreamInfo) error {
 	r.Status.AtProvider.Domain = domain
 	// Update connection details
-	r.Status.AtProvider.Connection.Address = client.Address
-	r.Status.AtProvider.Connection.UserPublicKey = client.UserPublicKey
-	r.Status.AtProvider.Connection.AccountPublicKey = client.AccountPublicKey
+	conn := client.Connection()
+	r.Status.AtProvider.Connection.Address = conn.Address
+	r.Status.AtProvider.Connection.UserPublicKey = conn.UserPublicKey
+	r.Status.AtProvider.Connection.AccountPublicKey = conn.AccountPublicKey
 
 	// Update status information for stream info
 	r.Status.AtProvider.State.Bytes = humanize.Bytes(data.State.Bytes)
+	r.Status.AtProvider.State.NumBytes = data.State.Bytes
 	r.Status.AtProvider.State.Messages = data.State.Msgs
 	r.Status.AtProvider.State.FirstSequence = data.State.FirstSeq
 	r.Status.AtProvider.State.LastSequence = data.State.LastSeq
@@ -186,21 +269,41 @@ func (c *external) setStatus(client *nats.Client, domain string, r *v1alpha1.Str
 		}
 		r.Status.AtProvider.ClusterInfo.Name = data.Cluster.Name
 	}
+
+	var maxReplicaLag *uint64
+	if r.Spec.ForProvider.Health != nil {
+		maxReplicaLag = r.Spec.ForProvider.Health.MaxReplicaLag
+	}
+	r.SetConditions(health.ClusterConditions(data.Cluster, maxReplicaLag)...)
 	return nil
 }
 
-func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	client, err := nats.NewClient(c.creds)
-	if err != nil {
-		return managed.ExternalObservation{}, err
+// connectionDetails returns everything a client needs to publish into the stream.
+func connectionDetails(client nats.JetStream, domain string, config *natsgo.StreamConfig) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		connectionKeyStream:    []byte(config.Name),
+		connectionKeyDomain:    []byte(domain),
+		connectionKeySubjects:  []byte(strings.Join(config.Subjects, ",")),
+		connectionKeyAddress:   []byte(client.Connection().Address),
+		connectionKeyAPIPrefix: []byte(client.APIPrefix(domain)),
 	}
-	defer func() {
-		client.Disconnect()
-	}()
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	r, ok := mg.(*v1alpha1.Stream)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotStream)
 	}
+	client, err := c.client(r)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	policy := r.Spec.ManagementPolicy
+	if meta.WasDeleted(r) && !policy.ShouldDelete() {
+		// Report the stream as gone so that the managed resource is finalized
+		// without deleting the stream.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
 	externalName, err := getExternalName(r)
 	if err != nil {
 		return managed.ExternalObservation{}, err
@@ -208,7 +311,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	domain := r.Spec.ForProvider.Domain
 
-	data, err := nats.StreamInfo(client, domain, externalName)
+	data, err := client.StreamInfo(ctx, domain, externalName)
 	if err != nil {
 		r.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
 		return managed.ExternalObservation{}, err
@@ -216,11 +319,34 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	if data == nil {
 		r.SetConditions(xpv1.Unavailable())
+		if !policy.ShouldCreate() {
+			return managed.ExternalObservation{}, errors.New(errObserveOnly)
+		}
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
+	lateInitialized, err := stream.LateInitialize(&r.Spec.ForProvider.Config, &data.Config)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if !policy.ShouldUpdate() {
+		// The stream is never changed, so it is reported as up to date
+		// regardless of the desired configuration.
+		if err := c.setStatus(client, domain, r, data); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		r.SetConditions(xpv1.Available())
+		return managed.ExternalObservation{
+			ResourceExists:          true,
+			ResourceUpToDate:        true,
+			ResourceLateInitialized: lateInitialized,
+			ConnectionDetails:       connectionDetails(client, domain, &data.Config),
+		}, nil
+	}
+
 	customConfig := r.Spec.ForProvider.Config
 	converted, err := stream.ConfigV1Alpha1ToNats(externalName, &customConfig)
 	if err != nil {
@@ -231,22 +357,8 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	converted.Name = externalName
 
-	oriJson, err := json.Marshal(data.Config)
-	if err != nil {
-		return managed.ExternalObservation{}, err
-	}
-	convertedJson, err := json.Marshal(converted)
-	if err != nil {
-		return managed.ExternalObservation{}, err
-	}
-
-	if !bytes.Equal(oriJson, convertedJson) {
-		return managed.ExternalObservation{
-			ResourceExists:    true,
-			ResourceUpToDate:  false,
-			ConnectionDetails: managed.ConnectionDetails{},
-		}, nil
-	}
+	c.diff = stream.DiffNatsConfig(converted, &data.Config)
+	c.immutable = stream.ImmutableDiff(converted, &data.Config)
 
 	err = c.setStatus(client, domain, r, data)
 	if err != nil {
@@ -255,6 +367,14 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	r.SetConditions(xpv1.Available())
 
+	// Drift is reported through the diff of the observation and an event. The
+	// reconciler sets the Synced condition from the result of the following
+	// update, which therefore reports the fields as well.
+	fields := strings.Join(c.diff, ", ")
+	if len(c.diff) > 0 {
+		c.recorder.Event(r, event.Normal(reasonDriftDetected, fmt.Sprintf(errDrift, fields)))
+	}
+
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
 		// the managed resource reconciler know that it needs to call Create to
@@ -263,27 +383,60 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
-		// resource reconciler know that it needs to call Update.
-		ResourceUpToDate: true,
+		// resource reconciler know that it needs to call Update. A pending
+		// purge request is run by Update as well.
+		ResourceUpToDate: len(c.diff) == 0 && !r.Spec.ForProvider.Purge.Pending(r.Status.AtProvider.LastPurge),
+
+		// Return true when fields of the spec left unset by the user were
+		// set to the values picked by the server.
+		ResourceLateInitialized: lateInitialized,
 
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(client, domain, &data.Config),
+
+		// Return the fields that differ from the desired state.
+		Diff: fields,
 	}, nil
 }
 
-func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	client, err := nats.NewClient(c.creds)
-	if err != nil {
-		return managed.ExternalCreation{}, err
+// waitForSources returns an error until all Streams referenced as mirror or
+// sources of the stream are Ready, so that sources are created first.
+func (c *external) waitForSources(ctx context.Context, r *v1alpha1.Stream) error {
+	sources := r.Spec.ForProvider.Config.Sources
+	if r.Spec.ForProvider.Config.Mirror != nil {
+		sources = append([]*stream.StreamSource{r.Spec.ForProvider.Config.Mirror}, sources...)
+	}
+	for _, source := range sources {
+		if source.NameRef == nil {
+			continue
+		}
+		s := &v1alpha1.Stream{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: source.NameRef.Name}, s); err != nil {
+			return errors.Wrap(err, errGetSource)
+		}
+		if s.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+			return errors.Errorf(errSourceNotReady, source.NameRef.Name)
+		}
 	}
-	defer func() {
-		client.Disconnect()
-	}()
+	return nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	r, ok := mg.(*v1alpha1.Stream)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotStream)
 	}
+	client, err := c.client(r)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if !r.Spec.ManagementPolicy.ShouldCreate() {
+		return managed.ExternalCreation{}, errors.New(errObserveOnly)
+	}
+	if err := c.waitForSources(ctx, r); err != nil {
+		return managed.ExternalCreation{}, err
+	}
 	c.log.Info("Creating", "stream", r)
 
 	customConfig := r.Spec.ForProvider.Config
@@ -298,7 +451,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 	config.Name = externalName
 
-	err = client.CreateStream(domain, config)
+	err = client.CreateStream(ctx, domain, config)
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
@@ -306,22 +459,22 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(client, domain, config),
 	}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	client, err := nats.NewClient(c.creds)
-	if err != nil {
-		return managed.ExternalUpdate{}, err
-	}
-	defer func() {
-		client.Disconnect()
-	}()
 	r, ok := mg.(*v1alpha1.Stream)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotStream)
 	}
+	client, err := c.client(r)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if !r.Spec.ManagementPolicy.ShouldUpdate() {
+		return managed.ExternalUpdate{}, nil
+	}
 	c.log.Info("Updating", "stream", r)
 	customConfig := r.Spec.ForProvider.Config
 	domain := r.Spec.ForProvider.Domain
@@ -334,30 +487,180 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, err
 	}
 	config.Name = externalName
-	err = client.UpdateStream(domain, config)
-	if err != nil {
-		return managed.ExternalUpdate{}, err
+
+	if len(c.immutable) > 0 {
+		err = c.recreate(ctx, client, domain, r, config)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		c.purge(ctx, client, domain, r)
+		return managed.ExternalUpdate{
+			ConnectionDetails: connectionDetails(client, domain, config),
+		}, c.driftUpdated()
+	}
+
+	// Update is also called to run a pending purge of an otherwise up to
+	// date stream, which is not updated then.
+	if len(c.diff) > 0 {
+		err = client.UpdateStream(ctx, domain, config)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, errUpdateFields, strings.Join(c.diff, ", "))
+		}
 	}
+	c.purge(ctx, client, domain, r)
 
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
-	}, nil
+		ConnectionDetails: connectionDetails(client, domain, config),
+	}, c.driftUpdated()
 }
 
-func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
-	client, err := nats.NewClient(c.creds)
+// driftUpdated returns an error naming the fields that differed from the
+// desired state, so that the reconciler reports them in the Synced condition
+// even though the update succeeded. The following observation finds the stream
+// up to date and reports it as synced.
+func (c *external) driftUpdated() error {
+	if len(c.diff) == 0 {
+		return nil
+	}
+	return errors.Errorf(errDriftUpdated, strings.Join(c.diff, ", "))
+}
+
+// purge runs a pending purge request of the stream and records its result in
+// the status. A failed purge is recorded as well and only retried once the
+// generation of the request changes.
+//
+// The generation is written to the status before the request, so that a purge
+// runs at most once even if its result cannot be written afterwards. A purge
+// whose result was not written is reported with errPurgeUnknown.
+//
+// The stream passed to Update may be stale by the time the purge runs, so the
+// generation is written to the latest version of the stream, whose resource
+// version is taken over so that the status write of the reconciler afterwards
+// does not conflict.
+func (c *external) purge(ctx context.Context, client nats.JetStream, domain string, r *v1alpha1.Stream) {
+	p := r.Spec.ForProvider.Purge
+	if !p.Pending(r.Status.AtProvider.LastPurge) {
+		return
+	}
+	current := &v1alpha1.Stream{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: r.GetName()}, current); err != nil {
+		c.recorder.Event(r, event.Warning(reasonPurgeFailed, errors.Wrap(err, errRecordPurge)))
+		return
+	}
+	if !p.Pending(current.Status.AtProvider.LastPurge) {
+		r.Status.AtProvider.LastPurge = current.Status.AtProvider.LastPurge
+		return
+	}
+	current.Status.AtProvider.LastPurge = &apisv1alpha1.StreamPurgeStatus{
+		Generation: p.Generation,
+		Time:       metav1.Now(),
+		Error:      errPurgeUnknown,
+	}
+	if err := c.kube.Status().Update(ctx, current); err != nil {
+		c.recorder.Event(r, event.Warning(reasonPurgeFailed, errors.Wrap(err, errRecordPurge)))
+		return
+	}
+	r.SetResourceVersion(current.GetResourceVersion())
+	r.Status.AtProvider.LastPurge = current.Status.AtProvider.LastPurge
+
+	name := meta.GetExternalName(r)
+	c.log.Info("Purging", "stream", name, "generation", p.Generation)
+	purged, err := client.PurgeStream(ctx, domain, name, &natsgo.StreamPurgeRequest{
+		Subject:  p.Subject,
+		Keep:     p.Keep,
+		Sequence: p.Sequence,
+	})
+	result := &apisv1alpha1.StreamPurgeStatus{
+		Generation: p.Generation,
+		Time:       metav1.Now(),
+		Purged:     purged,
+	}
 	if err != nil {
-		return err
+		result.Error = err.Error()
+		c.recorder.Event(r, event.Warning(reasonPurgeFailed, errors.Wrap(err, errPurge)))
+	} else {
+		c.recorder.Event(r, event.Normal(reasonPurged, fmt.Sprintf("Purged %d messages", purged)))
+	}
+	r.Status.AtProvider.LastPurge = result
+}
+
+// recreate deletes and creates the stream again according to the update policy
+// of the stream, as changes to immutable fields cannot be applied by an update.
+// With RecreateWithBackup the stream is snapshotted to its backup target first
+// and restored from the snapshot if it cannot be created again.
+func (c *external) recreate(ctx context.Context, client nats.JetStream, domain string, r *v1alpha1.Stream, config *natsgo.StreamConfig) error {
+	fields := strings.Join(c.immutable, ", ")
+	policy := r.Spec.ForProvider.UpdatePolicy
+	switch policy {
+	case stream.UpdatePolicyRecreate, stream.UpdatePolicyRecreateWithBackup:
+	default:
+		return errors.Errorf(errImmutable, fields)
+	}
+	if !r.Spec.ManagementPolicy.ShouldDelete() {
+		return errors.Errorf(errRecreatePolicy, fields, r.Spec.ManagementPolicy)
+	}
+
+	dir := ""
+	if policy == stream.UpdatePolicyRecreateWithBackup {
+		if r.Spec.ForProvider.Backup == nil {
+			return errors.New(errNoBackupTarget)
+		}
+		store, err := snapshot.New(ctx, c.kube, r.Spec.ForProvider.Backup)
+		if err != nil {
+			return errors.Wrap(err, errBackup)
+		}
+		dir, err = os.MkdirTemp("", "provider-nats-snapshot-")
+		if err != nil {
+			return errors.Wrap(err, errBackup)
+		}
+		defer os.RemoveAll(dir) //nolint:errcheck
+		if err := client.BackupStream(ctx, domain, config.Name, dir); err != nil {
+			return errors.Wrap(err, errBackup)
+		}
+		key := snapshot.Key(config.Name, fmt.Sprintf("recreate-%d", time.Now().Unix()))
+		if err := store.Put(ctx, key, dir); err != nil {
+			return errors.Wrap(err, errBackup)
+		}
+		c.recorder.Event(r, event.Normal(reasonBackedUp, fmt.Sprintf("Stream backed up to %s", store.Location(key))))
+	}
+
+	c.log.Info("Recreating", "stream", r, "fields", fields)
+	if err := client.DeleteStream(ctx, domain, config.Name); err != nil {
+		return errors.Wrap(err, errRecreate)
+	}
+	if err := client.CreateStream(ctx, domain, config); err != nil {
+		if dir == "" {
+			r.SetConditions(apisv1alpha1.RecreateFailed(err.Error()))
+			return errors.Wrap(err, errRecreate)
+		}
+		if _, rerr := client.RestoreStream(ctx, domain, config.Name, dir); rerr != nil {
+			r.SetConditions(apisv1alpha1.RecreateFailed(fmt.Sprintf(errRestoreFailed, err, rerr)))
+			return errors.Wrap(err, errRecreate)
+		}
+		r.SetConditions(apisv1alpha1.RestoredFromBackup(err.Error()))
+		c.recorder.Event(r, event.Warning(reasonRestored, errors.Wrap(err, errRecreate)))
+		return errors.Wrap(err, errRecreate)
 	}
-	defer func() {
-		client.Disconnect()
-	}()
+	r.SetConditions(apisv1alpha1.Recreated())
+	c.recorder.Event(r, event.Normal(reasonRecreated, fmt.Sprintf("Stream recreated to change immutable fields %s", fields)))
+
+	return nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	r, ok := mg.(*v1alpha1.Stream)
 	if !ok {
 		return errors.New(errNotStream)
 	}
+	client, err := c.client(r)
+	if err != nil {
+		return err
+	}
+	if !r.Spec.ManagementPolicy.ShouldDelete() {
+		return nil
+	}
 	c.log.Info("Deleting", "stream", r)
 	domain := r.Spec.ForProvider.Domain
 	externalName, err := getExternalName(r)
@@ -365,5 +668,5 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return err
 	}
 
-	return client.DeleteStream(domain, externalName)
+	return client.DeleteStream(ctx, domain, externalName)
 }
@@ -18,13 +18,40 @@ package stream
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	resourcefake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+	"github.com/edgefarm/provider-nats/internal/clients/nats/fake"
+	"github.com/edgefarm/provider-nats/internal/clients/nats/natstest"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -35,9 +62,155 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-func TestObserve(t *testing.T) {
-	type fields struct {
+const testDomain = "foo"
+
+type streamModifier func(*v1alpha1.Stream)
+
+func withDomain(domain string) streamModifier {
+	return func(r *v1alpha1.Stream) { r.Spec.ForProvider.Domain = domain }
+}
+
+func withAPIPrefix(prefix string) streamModifier {
+	return func(r *v1alpha1.Stream) { r.Spec.ForProvider.APIPrefix = prefix }
+}
+
+func withPolicy(p apisv1alpha1.ManagementPolicy) streamModifier {
+	return func(r *v1alpha1.Stream) { r.Spec.ManagementPolicy = p }
+}
+
+func withSubjects(subjects ...string) streamModifier {
+	return func(r *v1alpha1.Stream) { r.Spec.ForProvider.Config.Subjects = subjects }
+}
+
+func withStorage(storage string) streamModifier {
+	return func(r *v1alpha1.Stream) { r.Spec.ForProvider.Config.Storage = storage }
+}
+
+func withUpdatePolicy(p string) streamModifier {
+	return func(r *v1alpha1.Stream) { r.Spec.ForProvider.UpdatePolicy = p }
+}
+
+func withBackup(target *apisv1alpha1.BackupTarget) streamModifier {
+	return func(r *v1alpha1.Stream) { r.Spec.ForProvider.Backup = target }
+}
+
+func withPurge(p *apisv1alpha1.StreamPurge) streamModifier {
+	return func(r *v1alpha1.Stream) { r.Spec.ForProvider.Purge = p }
+}
+
+// withSourceRefs adds a source for each referenced Stream, named after the
+// Stream as if the reference was resolved.
+func withSourceRefs(names ...string) streamModifier {
+	return func(r *v1alpha1.Stream) {
+		for _, name := range names {
+			r.Spec.ForProvider.Config.Sources = append(r.Spec.ForProvider.Config.Sources,
+				&stream.StreamSource{Name: name, NameRef: &xpv1.Reference{Name: name}})
+		}
+	}
+}
+
+func withMirrorRef(name string) streamModifier {
+	return func(r *v1alpha1.Stream) {
+		r.Spec.ForProvider.Config.Subjects = nil
+		r.Spec.ForProvider.Config.Mirror = &stream.StreamSource{Name: name, NameRef: &xpv1.Reference{Name: name}}
+	}
+}
+
+func withDeletionTimestamp() streamModifier {
+	return func(r *v1alpha1.Stream) {
+		now := metav1.Now()
+		r.SetDeletionTimestamp(&now)
+	}
+}
+
+func newStream(name string, m ...streamModifier) *v1alpha1.Stream {
+	r := &v1alpha1.Stream{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.StreamSpec{
+			ForProvider: v1alpha1.StreamParameters{
+				Config: stream.StreamConfig{
+					Subjects: []string{name + ".>"},
+				},
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+func newExternal(s *natstest.Server) *external {
+	return &external{
+		log:            logging.NewNopLogger(),
+		creds:          s.Creds(),
+		newClient:      nats.NewPool().JetStream,
+		providerConfig: "default",
+		recorder:       event.NewNopRecorder(),
+	}
+}
+
+// getStreams returns a kube client that gets the supplied Streams by name.
+func getStreams(streams ...*v1alpha1.Stream) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			for _, s := range streams {
+				if s.GetName() == key.Name {
+					s.DeepCopyInto(obj.(*v1alpha1.Stream))
+					return nil
+				}
+			}
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "streams"}, key.Name)
+		},
+	}
+}
+
+func details(s *natstest.Server, domain string, name string, subjects string) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		connectionKeyStream:    []byte(name),
+		connectionKeyDomain:    []byte(domain),
+		connectionKeySubjects:  []byte(subjects),
+		connectionKeyAddress:   []byte(s.URL()),
+		connectionKeyAPIPrefix: []byte(nats.APIPrefix(domain)),
+	}
+}
+
+// prefixDetails returns the connection details of a stream managed through
+// an API prefix.
+func prefixDetails(s *natstest.Server, apiPrefix string, name string, subjects string) managed.ConnectionDetails {
+	cd := details(s, "", name, subjects)
+	cd[connectionKeyAPIPrefix] = []byte(apiPrefix)
+	return cd
+}
+
+// addStream creates a stream directly on the server, independently of the
+// code under test.
+func addStream(t *testing.T, s *natstest.Server, domain string, config *natsgo.StreamConfig) {
+	t.Helper()
+	if _, err := s.JetStream(t, domain).AddStream(config); err != nil {
+		t.Fatalf("cannot add stream %s: %v", config.Name, err)
+	}
+}
+
+func streamInfo(t *testing.T, s *natstest.Server, domain string, name string) *natsgo.StreamInfo {
+	t.Helper()
+	info, err := s.JetStream(t, domain).StreamInfo(name)
+	if errors.Is(err, natsgo.ErrStreamNotFound) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("cannot get stream %s: %v", name, err)
 	}
+	return info
+}
+
+func TestObserve(t *testing.T) {
+	s := natstest.Run(t, natstest.WithDomains(testDomain))
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "uptodate", Subjects: []string{"uptodate.>"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "drift", Subjects: []string{"drift.old"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "observed", Subjects: []string{"observed.>", "other.>"}})
+	addStream(t, s, testDomain, &natsgo.StreamConfig{Name: "leaf", Subjects: []string{"leaf.>"}})
 
 	type args struct {
 		ctx context.Context
@@ -51,17 +224,156 @@ func TestObserve(t *testing.T) {
 
 	cases := map[string]struct {
 		reason string
-		fields fields
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotStream": {
+			reason: "An error should be returned if the managed resource is not a Stream.",
+			args: args{
+				ctx: context.Background(),
+				mg:  nil,
+			},
+			want: want{
+				err: errors.New(errNotStream),
+			},
+		},
+		"DoesNotExist": {
+			reason: "A stream that does not exist on the server should be reported as missing.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("missing"),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ObserveOnlyDoesNotExist": {
+			reason: "An error should be returned if a stream with management policy ObserveOnly does not exist.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("missing", withPolicy(apisv1alpha1.ManagementObserveOnly)),
+			},
+			want: want{
+				err: errors.New(errObserveOnly),
+			},
+		},
+		"UpToDate": {
+			reason: "A stream that matches the desired configuration should be up to date and late initialized.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("uptodate"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       details(s, "", "uptodate", "uptodate.>"),
+				},
+			},
+		},
+		"UpToDateInDomain": {
+			reason: "A stream of a leaf node domain should be observed through the domain.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("leaf", withDomain(testDomain)),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       details(s, testDomain, "leaf", "leaf.>"),
+				},
+			},
+		},
+		"UpToDateThroughAPIPrefix": {
+			reason: "A stream should be observed through the JetStream API of its API prefix.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("leaf", withAPIPrefix(nats.APIPrefix(testDomain))),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       prefixDetails(s, nats.APIPrefix(testDomain), "leaf", "leaf.>"),
+				},
+			},
+		},
+		"DomainAndAPIPrefix": {
+			reason: "An error should be returned if both domain and API prefix are set.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("leaf", withDomain(testDomain), withAPIPrefix(nats.APIPrefix(testDomain))),
+			},
+			want: want{
+				err: errors.New(errDomainAPIPrefix),
+			},
+		},
+		"Drift": {
+			reason: "A stream that differs from the desired configuration should not be up to date.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("drift"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       details(s, "", "drift", "drift.old"),
+					Diff:                    "Subjects",
+				},
+			},
+		},
+		"ObserveOnly": {
+			reason: "A stream with management policy ObserveOnly should be up to date regardless of the desired configuration.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("observed", withPolicy(apisv1alpha1.ManagementObserveOnly)),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       details(s, "", "observed", "observed.>,other.>"),
+				},
+			},
+		},
+		"PurgePending": {
+			reason: "A stream with a pending purge request should not be up to date.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("uptodate", withPurge(&apisv1alpha1.StreamPurge{Generation: 1})),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       details(s, "", "uptodate", "uptodate.>"),
+				},
+			},
+		},
+		"OrphanOnDelete": {
+			reason: "A deleted stream with management policy OrphanOnDelete should be reported as missing.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("uptodate", withPolicy(apisv1alpha1.ManagementOrphanOnDelete), withDeletionTimestamp()),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		tc := tc
 		t.Run(name, func(t *testing.T) {
-			e := external{}
+			e := newExternal(s)
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -72,3 +384,678 @@ func TestObserve(t *testing.T) {
 		})
 	}
 }
+
+func TestObserveStatus(t *testing.T) {
+	connection := nats.Connection{Address: "nats://nats:4222", UserPublicKey: "UABC", AccountPublicKey: "ABC"}
+	cluster := func(leader string, replicas ...*natsgo.PeerInfo) func(*natsgo.StreamInfo) {
+		return func(info *natsgo.StreamInfo) {
+			info.Cluster = &natsgo.ClusterInfo{Name: "nats", Leader: leader, Replicas: replicas}
+		}
+	}
+
+	type args struct {
+		mg     *v1alpha1.Stream
+		stream func(*natsgo.StreamInfo)
+		err    error
+	}
+
+	type want struct {
+		observation v1alpha1.StreamObservation
+		conditions  []xpv1.Condition
+		err         error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Available": {
+			reason: "The state of the stream and the connection should be reported in the status.",
+			args: args{
+				mg: newStream("orders"),
+				stream: func(info *natsgo.StreamInfo) {
+					info.State = natsgo.StreamState{Msgs: 3, Bytes: 2048, FirstSeq: 1, LastSeq: 3, Consumers: 1}
+				},
+			},
+			want: want{
+				observation: v1alpha1.StreamObservation{
+					Connection: stream.StreamObservationConnection{Address: "nats://nats:4222", UserPublicKey: "UABC", AccountPublicKey: "ABC"},
+					State:      stream.StreamObservationState{Bytes: "2.0 kB", NumBytes: 2048, Messages: 3, FirstSequence: 1, LastSequence: 3, ConsumerCount: 1},
+				},
+				conditions: []xpv1.Condition{xpv1.Available()},
+			},
+		},
+		"Drift": {
+			reason: "The status of a stream that differs from the desired configuration should be refreshed without a reconcile error.",
+			args: args{
+				mg: newStream("orders", withSubjects("invoices.>")),
+				stream: func(info *natsgo.StreamInfo) {
+					info.State = natsgo.StreamState{Msgs: 3, Bytes: 2048, FirstSeq: 1, LastSeq: 3}
+				},
+			},
+			want: want{
+				observation: v1alpha1.StreamObservation{
+					Connection: stream.StreamObservationConnection{Address: "nats://nats:4222", UserPublicKey: "UABC", AccountPublicKey: "ABC"},
+					State:      stream.StreamObservationState{Bytes: "2.0 kB", NumBytes: 2048, Messages: 3, FirstSequence: 1, LastSequence: 3},
+				},
+				conditions: []xpv1.Condition{xpv1.Available(), {Type: xpv1.TypeSynced, Status: corev1.ConditionUnknown}},
+			},
+		},
+		"LeaderElected": {
+			reason: "A stream with a leader and healthy replicas should be reported as such.",
+			args: args{
+				mg:     newStream("orders"),
+				stream: cluster("nats-0", &natsgo.PeerInfo{Name: "nats-1", Current: true}),
+			},
+			want: want{
+				conditions: []xpv1.Condition{xpv1.Available(), apisv1alpha1.LeaderElected("nats-0"), apisv1alpha1.ReplicasHealthy()},
+			},
+		},
+		"NoLeader": {
+			reason: "A stream without a leader and an offline replica should be reported as unhealthy.",
+			args: args{
+				mg:     newStream("orders"),
+				stream: cluster("", &natsgo.PeerInfo{Name: "nats-1", Offline: true}),
+			},
+			want: want{
+				conditions: []xpv1.Condition{
+					apisv1alpha1.NoLeader(),
+					apisv1alpha1.ReplicasUnhealthy(apisv1alpha1.ReasonReplicaOffline, "Offline replicas: nats-1"),
+				},
+			},
+		},
+		"ServerError": {
+			reason: "An error of the server should be returned and the stream reported as unavailable.",
+			args: args{
+				mg:  newStream("orders"),
+				err: errors.New("nats: timeout"),
+			},
+			want: want{
+				conditions: []xpv1.Condition{xpv1.Unavailable().WithMessage("nats: timeout")},
+				err:        errors.New("nats: timeout"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			js := fake.New(connection)
+			if err := js.CreateStream(context.Background(), "", &natsgo.StreamConfig{Name: "orders", Subjects: []string{"orders.>"}}); err != nil {
+				t.Fatalf("cannot create stream: %v", err)
+			}
+			if tc.args.stream != nil {
+				tc.args.stream(js.Stream("", "orders"))
+			}
+			js.Err = tc.args.err
+
+			e := &external{
+				log:       logging.NewNopLogger(),
+				newClient: js.Factory(),
+				recorder:  event.NewNopRecorder(),
+			}
+			_, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.observation.Connection.Address != "" {
+				got := tc.args.mg.Status.AtProvider
+				if diff := cmp.Diff(tc.want.observation.Connection, got.Connection); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want connection, +got connection:\n%s\n", tc.reason, diff)
+				}
+				ignoreTimestamps := cmp.FilterPath(func(p cmp.Path) bool {
+					return p.Last().String() == ".FirstTimestamp" || p.Last().String() == ".LastTimestamp"
+				}, cmp.Ignore())
+				if diff := cmp.Diff(tc.want.observation.State, got.State, ignoreTimestamps); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want state, +got state:\n%s\n", tc.reason, diff)
+				}
+			}
+			for _, c := range tc.want.conditions {
+				if diff := cmp.Diff(c, tc.args.mg.GetCondition(c.Type), test.EquateConditions()); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s := natstest.Run(t, natstest.WithDomains(testDomain))
+
+	type args struct {
+		ctx context.Context
+		mg  *v1alpha1.Stream
+	}
+
+	type want struct {
+		c      managed.ExternalCreation
+		err    error
+		domain string
+		exists bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Create": {
+			reason: "The stream should be created on the server.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("orders"),
+			},
+			want: want{
+				c:      managed.ExternalCreation{ConnectionDetails: details(s, "", "orders", "orders.>")},
+				exists: true,
+			},
+		},
+		"CreateInDomain": {
+			reason: "The stream should be created in the JetStream domain of the leaf node.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("invoices", withDomain(testDomain)),
+			},
+			want: want{
+				c:      managed.ExternalCreation{ConnectionDetails: details(s, testDomain, "invoices", "invoices.>")},
+				domain: testDomain,
+				exists: true,
+			},
+		},
+		"CreateThroughAPIPrefix": {
+			reason: "The stream should be created through the JetStream API of its API prefix.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("shipments", withAPIPrefix(nats.APIPrefix(testDomain))),
+			},
+			want: want{
+				c:      managed.ExternalCreation{ConnectionDetails: prefixDetails(s, nats.APIPrefix(testDomain), "shipments", "shipments.>")},
+				domain: testDomain,
+				exists: true,
+			},
+		},
+		"SourcesReady": {
+			reason: "The stream should be created once all referenced sources are ready.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("aggregate", withSourceRefs("ready", "other")),
+			},
+			want: want{
+				c:      managed.ExternalCreation{ConnectionDetails: details(s, "", "aggregate", "aggregate.>")},
+				exists: true,
+			},
+		},
+		"SourceNotReady": {
+			reason: "The stream should not be created while a referenced source is not ready.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("waiting", withSourceRefs("ready", "pending")),
+			},
+			want: want{
+				err: errors.Errorf(errSourceNotReady, "pending"),
+			},
+		},
+		"MirrorNotReady": {
+			reason: "The stream should not be created while the referenced mirror is not ready.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("replica", withMirrorRef("pending")),
+			},
+			want: want{
+				err: errors.Errorf(errSourceNotReady, "pending"),
+			},
+		},
+		"SourceNotFound": {
+			reason: "An error should be returned if a referenced source does not exist.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("orphaned", withSourceRefs("missing")),
+			},
+			want: want{
+				err: errors.Wrap(kerrors.NewNotFound(schema.GroupResource{Resource: "streams"}, "missing"), errGetSource),
+			},
+		},
+		"ObserveOnly": {
+			reason: "A stream with management policy ObserveOnly should never be created.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("observed", withPolicy(apisv1alpha1.ManagementObserveOnly)),
+			},
+			want: want{
+				err: errors.New(errObserveOnly),
+			},
+		},
+	}
+
+	ready := newStream("ready")
+	ready.SetConditions(xpv1.Available())
+	other := newStream("other")
+	other.SetConditions(xpv1.Available())
+	kube := getStreams(ready, other, newStream("pending"))
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := newExternal(s)
+			e.kube = kube
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			info := streamInfo(t, s, tc.want.domain, tc.args.mg.GetName())
+			if exists := info != nil; exists != tc.want.exists {
+				t.Errorf("\n%s\ne.Create(...): want stream exists %t, got %t\n", tc.reason, tc.want.exists, exists)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s := natstest.Run(t)
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "subjects", Subjects: []string{"subjects.old"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "immutable", Subjects: []string{"immutable.>"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "purge", Subjects: []string{"purge.>"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "unrecorded", Subjects: []string{"unrecorded.>"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "recreate", Subjects: []string{"recreate.>"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "backup", Subjects: []string{"backup.>"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "notarget", Subjects: []string{"notarget.>"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "restore", Subjects: []string{"restore.>"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "taken", Subjects: []string{"taken.>"}})
+	js := s.JetStream(t, "")
+	for _, subject := range []string{"purge", "unrecorded", "recreate", "backup", "restore"} {
+		for i := 0; i < 3; i++ {
+			if _, err := js.Publish(subject+".msg", []byte(fmt.Sprint(i))); err != nil {
+				t.Fatalf("cannot publish message: %v", err)
+			}
+		}
+	}
+	backup := &apisv1alpha1.BackupTarget{Path: t.TempDir()}
+
+	type args struct {
+		ctx       context.Context
+		mg        *v1alpha1.Stream
+		diff      []string
+		immutable []string
+		// statusErr is returned when the status is written.
+		statusErr error
+	}
+
+	type want struct {
+		u   managed.ExternalUpdate
+		err error
+		// failed is set if an error of the server is expected, whose
+		// message is not compared.
+		failed    bool
+		subjects  []string
+		storage   natsgo.StorageType
+		msgs      uint64
+		purge     *apisv1alpha1.StreamPurgeStatus
+		recreated xpv1.ConditionReason
+		snapshots int
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Update": {
+			reason: "Fields that differ from the desired configuration should be updated and reported.",
+			args: args{
+				ctx:  context.Background(),
+				mg:   newStream("subjects"),
+				diff: []string{"Subjects"},
+			},
+			want: want{
+				u:        managed.ExternalUpdate{ConnectionDetails: details(s, "", "subjects", "subjects.>")},
+				err:      errors.Errorf(errDriftUpdated, "Subjects"),
+				subjects: []string{"subjects.>"},
+				storage:  natsgo.FileStorage,
+			},
+		},
+		"RejectImmutable": {
+			reason: "Changes to immutable fields should be rejected with update policy Reject.",
+			args: args{
+				ctx:       context.Background(),
+				mg:        newStream("immutable", withStorage("Memory")),
+				diff:      []string{"Storage"},
+				immutable: []string{"Storage"},
+			},
+			want: want{
+				err:      errors.Errorf(errImmutable, "Storage"),
+				subjects: []string{"immutable.>"},
+				storage:  natsgo.FileStorage,
+			},
+		},
+		"Recreate": {
+			reason: "The stream should be recreated to change immutable fields with update policy Recreate.",
+			args: args{
+				ctx:       context.Background(),
+				mg:        newStream("recreate", withStorage("Memory"), withUpdatePolicy(stream.UpdatePolicyRecreate)),
+				diff:      []string{"Storage"},
+				immutable: []string{"Storage"},
+			},
+			want: want{
+				u:         managed.ExternalUpdate{ConnectionDetails: details(s, "", "recreate", "recreate.>")},
+				err:       errors.Errorf(errDriftUpdated, "Storage"),
+				subjects:  []string{"recreate.>"},
+				storage:   natsgo.MemoryStorage,
+				recreated: apisv1alpha1.ReasonRecreated,
+			},
+		},
+		"RecreateWithBackup": {
+			reason: "The stream should be snapshotted to its backup target before it is recreated.",
+			args: args{
+				ctx:       context.Background(),
+				mg:        newStream("backup", withStorage("Memory"), withUpdatePolicy(stream.UpdatePolicyRecreateWithBackup), withBackup(backup)),
+				diff:      []string{"Storage"},
+				immutable: []string{"Storage"},
+			},
+			want: want{
+				u:         managed.ExternalUpdate{ConnectionDetails: details(s, "", "backup", "backup.>")},
+				err:       errors.Errorf(errDriftUpdated, "Storage"),
+				subjects:  []string{"backup.>"},
+				storage:   natsgo.MemoryStorage,
+				recreated: apisv1alpha1.ReasonRecreated,
+				snapshots: 1,
+			},
+		},
+		"RecreateWithBackupNoTarget": {
+			reason: "A stream without backup target should not be recreated with update policy RecreateWithBackup.",
+			args: args{
+				ctx:       context.Background(),
+				mg:        newStream("notarget", withStorage("Memory"), withUpdatePolicy(stream.UpdatePolicyRecreateWithBackup)),
+				diff:      []string{"Storage"},
+				immutable: []string{"Storage"},
+			},
+			want: want{
+				err:      errors.New(errNoBackupTarget),
+				subjects: []string{"notarget.>"},
+				storage:  natsgo.FileStorage,
+			},
+		},
+		"RestoreFromBackup": {
+			reason: "A stream that cannot be created again should be restored from its snapshot.",
+			args: args{
+				ctx:       context.Background(),
+				mg:        newStream("restore", withSubjects("taken.>"), withStorage("Memory"), withUpdatePolicy(stream.UpdatePolicyRecreateWithBackup), withBackup(backup)),
+				diff:      []string{"Storage", "Subjects"},
+				immutable: []string{"Storage"},
+			},
+			want: want{
+				failed:    true,
+				subjects:  []string{"restore.>"},
+				storage:   natsgo.FileStorage,
+				msgs:      3,
+				recreated: apisv1alpha1.ReasonRestoredFromBackup,
+				snapshots: 1,
+			},
+		},
+		"Purge": {
+			reason: "A pending purge request should be run and recorded in the status.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("purge", withPurge(&apisv1alpha1.StreamPurge{Generation: 1, Keep: 1})),
+			},
+			want: want{
+				u:        managed.ExternalUpdate{ConnectionDetails: details(s, "", "purge", "purge.>")},
+				subjects: []string{"purge.>"},
+				storage:  natsgo.FileStorage,
+				msgs:     1,
+				purge:    &apisv1alpha1.StreamPurgeStatus{Generation: 1, Purged: 2},
+			},
+		},
+		"PurgeNotRecorded": {
+			reason: "A purge request should not run if it cannot be recorded in the status before.",
+			args: args{
+				ctx:       context.Background(),
+				mg:        newStream("unrecorded", withPurge(&apisv1alpha1.StreamPurge{Generation: 1})),
+				statusErr: errors.New("conflict"),
+			},
+			want: want{
+				u:        managed.ExternalUpdate{ConnectionDetails: details(s, "", "unrecorded", "unrecorded.>")},
+				subjects: []string{"unrecorded.>"},
+				storage:  natsgo.FileStorage,
+				msgs:     3,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := newExternal(s)
+			e.kube = &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					tc.args.mg.DeepCopyInto(obj.(*v1alpha1.Stream))
+					return nil
+				},
+				MockStatusUpdate: test.NewMockStatusUpdateFn(tc.args.statusErr),
+			}
+			e.diff = tc.args.diff
+			e.immutable = tc.args.immutable
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if tc.want.failed {
+				if err == nil {
+					t.Errorf("\n%s\ne.Update(...): want error, got nil\n", tc.reason)
+				}
+			} else if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.u, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			info := streamInfo(t, s, "", tc.args.mg.GetName())
+			if diff := cmp.Diff(tc.want.subjects, info.Config.Subjects); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want subjects, +got subjects:\n%s\n", tc.reason, diff)
+			}
+			if info.Config.Storage != tc.want.storage {
+				t.Errorf("\n%s\ne.Update(...): want storage %s, got %s\n", tc.reason, tc.want.storage, info.Config.Storage)
+			}
+			if info.State.Msgs != tc.want.msgs {
+				t.Errorf("\n%s\ne.Update(...): want %d messages, got %d\n", tc.reason, tc.want.msgs, info.State.Msgs)
+			}
+			if got := tc.args.mg.GetCondition(apisv1alpha1.TypeRecreated).Reason; got != tc.want.recreated {
+				t.Errorf("\n%s\ne.Update(...): want recreated reason %q, got %q\n", tc.reason, tc.want.recreated, got)
+			}
+			snapshots, _ := os.ReadDir(filepath.Join(backup.Path, tc.args.mg.GetName()))
+			if len(snapshots) != tc.want.snapshots {
+				t.Errorf("\n%s\ne.Update(...): want %d snapshots, got %d\n", tc.reason, tc.want.snapshots, len(snapshots))
+			}
+			ignoreTime := cmp.FilterPath(func(p cmp.Path) bool { return p.Last().String() == ".Time" }, cmp.Ignore())
+			if diff := cmp.Diff(tc.want.purge, tc.args.mg.Status.AtProvider.LastPurge, ignoreTime); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want last purge, +got last purge:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcileDrift(t *testing.T) {
+	js := fake.New(nats.Connection{})
+	if err := js.CreateStream(context.Background(), "", &natsgo.StreamConfig{Name: "orders", Subjects: []string{"orders.>"}}); err != nil {
+		t.Fatalf("cannot create stream: %v", err)
+	}
+	mg := newStream("orders", withSubjects("invoices.>"))
+	mg.SetFinalizers([]string{"finalizer.managedresource.crossplane.io"})
+	store := func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*v1alpha1.Stream).DeepCopyInto(mg)
+		return nil
+	}
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			mg.DeepCopyInto(obj.(*v1alpha1.Stream))
+			return nil
+		},
+		MockUpdate:       store,
+		MockStatusUpdate: store,
+	}
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("cannot add to scheme: %v", err)
+	}
+	r := managed.NewReconciler(&resourcefake.Manager{Client: kube, Scheme: scheme},
+		resource.ManagedKind(v1alpha1.StreamGroupVersionKind),
+		managed.WithExternalConnecter(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+			return &external{log: logging.NewNopLogger(), newClient: js.Factory(), recorder: event.NewNopRecorder()}, nil
+		})),
+		managed.WithConnectionPublishers(),
+	)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "orders"}}
+
+	// The reconcile that finds the drift reports the fields in the Synced
+	// condition.
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("r.Reconcile(...): %v", err)
+	}
+	want := xpv1.ReconcileError(errors.Wrap(errors.Errorf(errDriftUpdated, "Subjects"), "update failed"))
+	if diff := cmp.Diff(want, mg.GetCondition(xpv1.TypeSynced), test.EquateConditions()); diff != "" {
+		t.Errorf("r.Reconcile(...): -want Synced, +got Synced:\n%s", diff)
+	}
+
+	// The following reconcile finds the stream up to date.
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("r.Reconcile(...): %v", err)
+	}
+	if diff := cmp.Diff(xpv1.ReconcileSuccess(), mg.GetCondition(xpv1.TypeSynced), test.EquateConditions()); diff != "" {
+		t.Errorf("r.Reconcile(...): -want Synced, +got Synced:\n%s", diff)
+	}
+}
+
+func TestReconcilePurgeConflict(t *testing.T) {
+	js := fake.New(nats.Connection{})
+	if err := js.CreateStream(context.Background(), "", &natsgo.StreamConfig{Name: "orders", Subjects: []string{"orders.>"}}); err != nil {
+		t.Fatalf("cannot create stream: %v", err)
+	}
+	js.Stream("", "orders").State.Msgs = 3
+	stored := newStream("orders", withPurge(&apisv1alpha1.StreamPurge{Generation: 1}))
+	stored.SetFinalizers([]string{"finalizer.managedresource.crossplane.io"})
+	version := 1
+	stored.SetResourceVersion(strconv.Itoa(version))
+	// store writes an object the way the API server does, rejecting writes
+	// of stale objects and bumping the resource version of the others.
+	store := func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		if obj.GetResourceVersion() != stored.GetResourceVersion() {
+			return kerrors.NewConflict(v1alpha1.StreamGroupVersionKind.GroupVersion().WithResource("streams").GroupResource(), obj.GetName(), errors.New("the object has been modified"))
+		}
+		version++
+		obj.SetResourceVersion(strconv.Itoa(version))
+		obj.(*v1alpha1.Stream).DeepCopyInto(stored)
+		return nil
+	}
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			stored.DeepCopyInto(obj.(*v1alpha1.Stream))
+			return nil
+		},
+		MockUpdate:       store,
+		MockStatusUpdate: store,
+	}
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("cannot add to scheme: %v", err)
+	}
+	r := managed.NewReconciler(&resourcefake.Manager{Client: kube, Scheme: scheme},
+		resource.ManagedKind(v1alpha1.StreamGroupVersionKind),
+		managed.WithExternalConnecter(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+			e := &external{log: logging.NewNopLogger(), kube: kube, newClient: js.Factory(), recorder: event.NewNopRecorder()}
+			return managed.ExternalClientFns{
+				ObserveFn: e.Observe,
+				UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+					// The stream is modified by someone else after the
+					// reconciler read it, so the stream passed to Update
+					// is stale.
+					version++
+					stored.SetResourceVersion(strconv.Itoa(version))
+					return e.Update(ctx, mg)
+				},
+			}, nil
+		})),
+		managed.WithConnectionPublishers(),
+	)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "orders"}}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("r.Reconcile(...): %v", err)
+	}
+	if diff := cmp.Diff(xpv1.ReconcileSuccess(), stored.GetCondition(xpv1.TypeSynced), test.EquateConditions()); diff != "" {
+		t.Errorf("r.Reconcile(...): -want Synced, +got Synced:\n%s", diff)
+	}
+	ignoreTime := cmp.FilterPath(func(p cmp.Path) bool { return p.Last().String() == ".Time" }, cmp.Ignore())
+	want := &apisv1alpha1.StreamPurgeStatus{Generation: 1, Purged: 3}
+	if diff := cmp.Diff(want, stored.Status.AtProvider.LastPurge, ignoreTime); diff != "" {
+		t.Errorf("r.Reconcile(...): -want last purge, +got last purge:\n%s", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := natstest.Run(t, natstest.WithDomains(testDomain))
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "orders", Subjects: []string{"orders.>"}})
+	addStream(t, s, "", &natsgo.StreamConfig{Name: "orphaned", Subjects: []string{"orphaned.>"}})
+	addStream(t, s, testDomain, &natsgo.StreamConfig{Name: "invoices", Subjects: []string{"invoices.>"}})
+
+	type args struct {
+		ctx context.Context
+		mg  *v1alpha1.Stream
+	}
+
+	type want struct {
+		err    error
+		exists bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Delete": {
+			reason: "The stream should be deleted from the server.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("orders"),
+			},
+			want: want{
+				exists: false,
+			},
+		},
+		"DeleteInDomain": {
+			reason: "The stream should be deleted from the JetStream domain of the leaf node.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("invoices", withDomain(testDomain)),
+			},
+			want: want{
+				exists: false,
+			},
+		},
+		"OrphanOnDelete": {
+			reason: "A stream with management policy OrphanOnDelete should be kept on the server.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newStream("orphaned", withPolicy(apisv1alpha1.ManagementOrphanOnDelete)),
+			},
+			want: want{
+				exists: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := newExternal(s)
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			info := streamInfo(t, s, tc.args.mg.Spec.ForProvider.Domain, tc.args.mg.GetName())
+			if exists := info != nil; exists != tc.want.exists {
+				t.Errorf("\n%s\ne.Delete(...): want stream exists %t, got %t\n", tc.reason, tc.want.exists, exists)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package streambackup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/edgefarm/provider-nats/apis/backup/v1alpha1"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+	"github.com/edgefarm/provider-nats/internal/controller/features"
+	"github.com/edgefarm/provider-nats/internal/snapshot"
+)
+
+const (
+	errNotStreamBackup = "managed resource is not a StreamBackup custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errGetConnectOpts  = "cannot get connect options"
+	errNoStream        = "either stream, streamRef or streamSelector must be set"
+	errNewStore        = "cannot access backup target"
+	errStatSnapshot    = "cannot get snapshot"
+	errSnapshot        = "cannot snapshot stream"
+	errPutSnapshot     = "cannot store snapshot"
+	errDeleteSnapshot  = "cannot delete snapshot"
+)
+
+// reconcileTimeout bounds a single reconcile, which includes taking and
+// uploading the snapshot of a stream.
+const reconcileTimeout = 15 * time.Minute
+
+// Setup adds a controller that reconciles StreamBackup managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.StreamBackupGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	connector := &connector{
+		kube:      mgr.GetClient(),
+		usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+		logger:    o.Logger,
+		newClient: nats.DefaultPool.JetStream,
+	}
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.StreamBackupGroupVersionKind),
+		managed.WithExternalConnecter(connector),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(reconcileTimeout),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.StreamBackup{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	logger    logging.Logger
+	newClient nats.JetStreamFactory
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.StreamBackup)
+	if !ok {
+		return nil, errors.New(errNotStreamBackup)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	creds, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	opts, err := nats.GetConnectOptions(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetConnectOpts)
+	}
+
+	e := &external{
+		kube:           c.kube,
+		creds:          creds,
+		log:            c.logger,
+		newClient:      c.newClient,
+		providerConfig: cr.GetProviderConfigReference().Name,
+		opts:           opts,
+	}
+
+	return e, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube           client.Client
+	log            logging.Logger
+	creds          []byte
+	newClient      nats.JetStreamFactory
+	providerConfig string
+	opts           *nats.ConnectOptions
+}
+
+const (
+	annotationExternalName = "crossplane.io/external-name"
+)
+
+func getExternalName(r *v1alpha1.StreamBackup) (string, error) {
+	annotations := r.GetAnnotations()
+	if annotations != nil {
+		if val, ok := annotations[annotationExternalName]; ok {
+			return val, nil
+		}
+	}
+	return "", fmt.Errorf("external name annotation not found for stream backup %s", r.GetName())
+}
+
+// snapshot returns the store of the backup target and the key of the
+// snapshot taken by the backup.
+func (c *external) snapshot(ctx context.Context, r *v1alpha1.StreamBackup) (snapshot.Store, string, error) {
+	if r.Spec.ForProvider.Stream == "" {
+		return nil, "", errors.New(errNoStream)
+	}
+	externalName, err := getExternalName(r)
+	if err != nil {
+		return nil, "", err
+	}
+	store, err := snapshot.New(ctx, c.kube, &r.Spec.ForProvider.Target)
+	if err != nil {
+		return nil, "", errors.Wrap(err, errNewStore)
+	}
+	return store, snapshot.Key(r.Spec.ForProvider.Stream, externalName), nil
+}
+
+func (c *external) setStatus(r *v1alpha1.StreamBackup, key string, info *snapshot.Info) {
+	r.Status.AtProvider.Stream = r.Spec.ForProvider.Stream
+	r.Status.AtProvider.Key = key
+	r.Status.AtProvider.Location = info.Location
+	r.Status.AtProvider.Size = info.Size
+	completed := metav1.NewTime(info.Completed)
+	r.Status.AtProvider.CompletionTime = &completed
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	r, ok := mg.(*v1alpha1.StreamBackup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotStreamBackup)
+	}
+
+	store, key, err := c.snapshot(ctx, r)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	info, err := store.Stat(ctx, key)
+	if err != nil {
+		r.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
+		return managed.ExternalObservation{}, errors.Wrap(err, errStatSnapshot)
+	}
+
+	if info == nil {
+		r.SetConditions(xpv1.Unavailable())
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	c.setStatus(r, key, info)
+
+	r.SetConditions(xpv1.Available())
+
+	// A snapshot is never updated, changes to the spec only take effect for
+	// new backups.
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	client, err := c.newClient(c.providerConfig, c.creds, c.opts)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	r, ok := mg.(*v1alpha1.StreamBackup)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotStreamBackup)
+	}
+	c.log.Info("Creating", "streambackup", r)
+
+	store, key, err := c.snapshot(ctx, r)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	dir, err := os.MkdirTemp("", "provider-nats-snapshot-")
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errSnapshot)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	if err := client.BackupStream(ctx, r.Spec.ForProvider.Domain, r.Spec.ForProvider.Stream, dir); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errSnapshot)
+	}
+	if err := store.Put(ctx, key, dir); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errPutSnapshot)
+	}
+
+	return managed.ExternalCreation{
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	r, ok := mg.(*v1alpha1.StreamBackup)
+	if !ok {
+		return errors.New(errNotStreamBackup)
+	}
+	c.log.Info("Deleting", "streambackup", r)
+
+	store, key, err := c.snapshot(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(store.Delete(ctx, key), errDeleteSnapshot)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package streambackup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/edgefarm/provider-nats/apis/backup/v1alpha1"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+	"github.com/edgefarm/provider-nats/internal/clients/nats/fake"
+	"github.com/edgefarm/provider-nats/internal/snapshot"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const testStream = "orders"
+
+type backupModifier func(*v1alpha1.StreamBackup)
+
+func withStream(stream string) backupModifier {
+	return func(r *v1alpha1.StreamBackup) { r.Spec.ForProvider.Stream = stream }
+}
+
+func newBackup(name string, root string, m ...backupModifier) *v1alpha1.StreamBackup {
+	r := &v1alpha1.StreamBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.StreamBackupSpec{
+			ForProvider: v1alpha1.StreamBackupParameters{
+				Stream: testStream,
+				Target: apisv1alpha1.BackupTarget{Path: root},
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+// newJetStream returns a fake JetStream with the test stream and a snapshot of
+// it stored under the key of the backup "nightly" in root.
+func newJetStream(t *testing.T, root string) *fake.JetStream {
+	t.Helper()
+	ctx := context.Background()
+	js := fake.New(nats.Connection{Address: "nats://nats:4222"})
+	if err := js.CreateStream(ctx, "", &natsgo.StreamConfig{Name: testStream}); err != nil {
+		t.Fatalf("cannot create stream: %v", err)
+	}
+	dir := t.TempDir()
+	if err := js.BackupStream(ctx, "", testStream, dir); err != nil {
+		t.Fatalf("cannot back up stream: %v", err)
+	}
+	if err := snapshot.NewDirectory(root).Put(ctx, snapshot.Key(testStream, "nightly"), dir); err != nil {
+		t.Fatalf("cannot store snapshot: %v", err)
+	}
+	return js
+}
+
+func newExternal(js *fake.JetStream) *external {
+	return &external{
+		log:       logging.NewNopLogger(),
+		newClient: js.Factory(),
+	}
+}
+
+func TestObserve(t *testing.T) {
+	root := t.TempDir()
+
+	type args struct {
+		ctx context.Context
+		mg  *v1alpha1.StreamBackup
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+		key string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotTaken": {
+			reason: "A backup without snapshot should not exist.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newBackup("hourly", root),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Taken": {
+			reason: "A backup with snapshot should exist, be up to date and report its snapshot.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newBackup("nightly", root),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				key: "orders/nightly",
+			},
+		},
+		"NoStream": {
+			reason: "A backup without stream should return an error.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newBackup("nightly", root, withStream("")),
+			},
+			want: want{
+				err: errors.New(errNoStream),
+			},
+		},
+	}
+
+	e := newExternal(newJetStream(t, root))
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.key, tc.args.mg.Status.AtProvider.Key); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want key, +got key:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	root := t.TempDir()
+
+	type args struct {
+		ctx context.Context
+		mg  *v1alpha1.StreamBackup
+	}
+
+	type want struct {
+		err   error
+		taken bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Create": {
+			reason: "The snapshot of the stream should be stored under the key of the backup.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newBackup("hourly", root),
+			},
+			want: want{
+				taken: true,
+			},
+		},
+		"StreamNotFound": {
+			reason: "A backup of a missing stream should return an error and store no snapshot.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newBackup("missing", root, withStream("invoices")),
+			},
+			want: want{
+				err: errors.Wrap(natsgo.ErrStreamNotFound, errSnapshot),
+			},
+		},
+	}
+
+	e := newExternal(newJetStream(t, root))
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			info, err := snapshot.NewDirectory(root).Stat(tc.args.ctx, snapshot.Key(tc.args.mg.Spec.ForProvider.Stream, tc.args.mg.GetName()))
+			if err != nil {
+				t.Fatalf("cannot stat snapshot: %v", err)
+			}
+			if taken := info != nil; taken != tc.want.taken {
+				t.Errorf("\n%s\ne.Create(...): want snapshot %t, got %t\n", tc.reason, tc.want.taken, taken)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	e := newExternal(newJetStream(t, root))
+
+	if err := e.Delete(ctx, newBackup("nightly", root)); err != nil {
+		t.Errorf("e.Delete(...): unexpected error: %v", err)
+	}
+	info, err := snapshot.NewDirectory(root).Stat(ctx, snapshot.Key(testStream, "nightly"))
+	if err != nil {
+		t.Fatalf("cannot stat snapshot: %v", err)
+	}
+	if info != nil {
+		t.Errorf("e.Delete(...): want snapshot deleted, got %s", info.Location)
+	}
+}
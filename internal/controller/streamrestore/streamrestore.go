@@ -0,0 +1,383 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package streamrestore
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/edgefarm/provider-nats/apis/backup/v1alpha1"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+	"github.com/edgefarm/provider-nats/internal/controller/features"
+	"github.com/edgefarm/provider-nats/internal/snapshot"
+)
+
+const (
+	errNotStreamRestore = "managed resource is not a StreamRestore custom resource"
+	errTrackPCUsage     = "cannot track ProviderConfig usage"
+	errGetPC            = "cannot get ProviderConfig"
+	errGetCreds         = "cannot get credentials"
+	errGetConnectOpts   = "cannot get connect options"
+	errNoSource         = "either backupRef or source must be set"
+	errAmbiguousSource  = "only one of backupRef or source may be set"
+	errNoStream         = "stream must be set when restoring from source"
+	errGetBackup        = "cannot get referenced StreamBackup"
+	errBackupNotReady   = "referenced StreamBackup %s is not ready"
+	errNewStore         = "cannot access backup target"
+	errGetSnapshot      = "cannot get snapshot"
+	errInvalidSnapshot  = "cannot restore snapshot"
+	errStreamMismatch   = "stream %s does not match stream %s of StreamBackup %s, JetStream cannot rename streams on restore"
+	errStreamExists     = "stream %s already exists, set spec.forProvider.replaceExisting to replace it"
+	errBackupExisting   = "cannot back up existing stream before replacing it"
+	errReplace          = "cannot delete existing stream"
+	errRestore          = "cannot restore stream"
+	errRollback         = "cannot restore stream: %v, cannot restore replaced stream: %v"
+)
+
+// reconcileTimeout bounds a single reconcile, which includes downloading and
+// restoring the snapshot of a stream.
+const reconcileTimeout = 15 * time.Minute
+
+// Setup adds a controller that reconciles StreamRestore managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.StreamRestoreGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	connector := &connector{
+		kube:      mgr.GetClient(),
+		usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+		logger:    o.Logger,
+		newClient: nats.DefaultPool.JetStream,
+	}
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.StreamRestoreGroupVersionKind),
+		managed.WithExternalConnecter(connector),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(reconcileTimeout),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.StreamRestore{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	logger    logging.Logger
+	newClient nats.JetStreamFactory
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.StreamRestore)
+	if !ok {
+		return nil, errors.New(errNotStreamRestore)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cd := pc.Spec.Credentials
+	creds, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	opts, err := nats.GetConnectOptions(ctx, c.kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetConnectOpts)
+	}
+
+	e := &external{
+		kube:           c.kube,
+		creds:          creds,
+		log:            c.logger,
+		newClient:      c.newClient,
+		providerConfig: cr.GetProviderConfigReference().Name,
+		opts:           opts,
+	}
+
+	return e, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	kube           client.Client
+	log            logging.Logger
+	creds          []byte
+	newClient      nats.JetStreamFactory
+	providerConfig string
+	opts           *nats.ConnectOptions
+}
+
+// source is a resolved snapshot and the stream it is restored into.
+type source struct {
+	stream   string
+	domain   string
+	store    snapshot.Store
+	key      string
+	location string
+}
+
+// source resolves the snapshot that is restored either from the referenced
+// StreamBackup or from the explicit source of the restore.
+func (c *external) source(ctx context.Context, r *v1alpha1.StreamRestore) (*source, error) {
+	p := r.Spec.ForProvider
+	s := &source{stream: p.Stream, domain: p.Domain}
+	var target *apisv1alpha1.BackupTarget
+
+	switch {
+	case p.BackupRef != nil && p.Source != nil:
+		return nil, errors.New(errAmbiguousSource)
+	case p.BackupRef != nil:
+		b := &v1alpha1.StreamBackup{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: p.BackupRef.Name}, b); err != nil {
+			return nil, errors.Wrap(err, errGetBackup)
+		}
+		if b.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+			return nil, errors.Errorf(errBackupNotReady, p.BackupRef.Name)
+		}
+		switch s.stream {
+		case "":
+			s.stream = b.Spec.ForProvider.Stream
+		case b.Spec.ForProvider.Stream:
+		default:
+			return nil, errors.Errorf(errStreamMismatch, s.stream, b.Spec.ForProvider.Stream, p.BackupRef.Name)
+		}
+		if s.domain == "" {
+			s.domain = b.Spec.ForProvider.Domain
+		}
+		target = &b.Spec.ForProvider.Target
+		s.key = b.Status.AtProvider.Key
+	case p.Source != nil:
+		if s.stream == "" {
+			return nil, errors.New(errNoStream)
+		}
+		target = &p.Source.Target
+		s.key = p.Source.Key
+	default:
+		return nil, errors.New(errNoSource)
+	}
+
+	store, err := snapshot.New(ctx, c.kube, target)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewStore)
+	}
+	s.store = store
+	s.location = store.Location(s.key)
+	return s, nil
+}
+
+// restored returns the source a snapshot was restored from as recorded in the
+// status, or resolves it if it was not recorded yet. The source is not resolved
+// again once recorded, as the referenced StreamBackup may since be deleted.
+func (c *external) restored(ctx context.Context, r *v1alpha1.StreamRestore) (*source, error) {
+	o := r.Status.AtProvider
+	if o.Stream != "" && o.Location != "" {
+		return &source{stream: o.Stream, domain: o.Domain, location: o.Location}, nil
+	}
+	return c.source(ctx, r)
+}
+
+func (c *external) setStatus(r *v1alpha1.StreamRestore, src *source, restored time.Time, messages uint64, bytes uint64) {
+	r.Status.AtProvider.Stream = src.stream
+	r.Status.AtProvider.Domain = src.domain
+	r.Status.AtProvider.Location = src.location
+	t := metav1.NewTime(restored)
+	r.Status.AtProvider.RestoreTime = &t
+	r.Status.AtProvider.Messages = messages
+	r.Status.AtProvider.Bytes = bytes
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	client, err := c.newClient(c.providerConfig, c.creds, c.opts)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	r, ok := mg.(*v1alpha1.StreamRestore)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotStreamRestore)
+	}
+
+	// Deleting a restore leaves the restored stream untouched, so there is
+	// nothing left to delete.
+	if meta.WasDeleted(r) {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	// The snapshot is restored exactly once. The reconciler records the
+	// successful restore in the external-create-succeeded annotation.
+	restored := meta.GetExternalCreateSucceeded(r)
+	if restored.IsZero() {
+		r.SetConditions(xpv1.Unavailable())
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	src, err := c.restored(ctx, r)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	data, err := client.StreamInfo(ctx, src.domain, src.stream)
+	if err != nil {
+		r.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
+		return managed.ExternalObservation{}, err
+	}
+
+	if data == nil {
+		c.setStatus(r, src, restored, 0, 0)
+		r.SetConditions(xpv1.Unavailable().WithMessage("restored stream no longer exists"))
+	} else {
+		c.setStatus(r, src, restored, data.State.Msgs, data.State.Bytes)
+		r.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	client, err := c.newClient(c.providerConfig, c.creds, c.opts)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	r, ok := mg.(*v1alpha1.StreamRestore)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotStreamRestore)
+	}
+	c.log.Info("Creating", "streamrestore", r)
+
+	src, err := c.source(ctx, r)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	existing, err := client.StreamInfo(ctx, src.domain, src.stream)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if existing != nil && !r.Spec.ForProvider.ReplaceExisting {
+		return managed.ExternalCreation{}, errors.Errorf(errStreamExists, src.stream)
+	}
+
+	dir, err := os.MkdirTemp("", "provider-nats-restore-")
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetSnapshot)
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	if err := src.store.Get(ctx, src.key, dir); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetSnapshot)
+	}
+
+	// JetStream cannot restore into a temporary stream and rename it, so the
+	// snapshot is validated before an existing stream is deleted.
+	if err := snapshot.Validate(dir, src.stream); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInvalidSnapshot)
+	}
+
+	// An existing stream is snapshotted before it is deleted, so that it can
+	// be restored again if the snapshot cannot be restored.
+	replaced := ""
+	if existing != nil {
+		replaced, err = os.MkdirTemp("", "provider-nats-replace-")
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errBackupExisting)
+		}
+		defer os.RemoveAll(replaced) //nolint:errcheck
+		if err := client.BackupStream(ctx, src.domain, src.stream, replaced); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errBackupExisting)
+		}
+		c.log.Info("Replacing existing stream", "stream", src.stream)
+		if err := client.DeleteStream(ctx, src.domain, src.stream); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errReplace)
+		}
+	}
+
+	if _, err := client.RestoreStream(ctx, src.domain, src.stream, dir); err != nil {
+		if replaced == "" {
+			return managed.ExternalCreation{}, errors.Wrap(err, errRestore)
+		}
+		// A partially restored stream is removed before the replaced
+		// stream is restored, the error is reported by the restore.
+		_ = client.DeleteStream(ctx, src.domain, src.stream)
+		if _, rerr := client.RestoreStream(ctx, src.domain, src.stream, replaced); rerr != nil {
+			return managed.ExternalCreation{}, errors.Errorf(errRollback, err, rerr)
+		}
+		return managed.ExternalCreation{}, errors.Wrap(err, errRestore)
+	}
+
+	return managed.ExternalCreation{
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	return nil
+}
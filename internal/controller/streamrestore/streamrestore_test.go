@@ -0,0 +1,510 @@
+/*
+Copyright 2023 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package streamrestore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/edgefarm/provider-nats/apis/backup/v1alpha1"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+	"github.com/edgefarm/provider-nats/internal/clients/nats/fake"
+	"github.com/edgefarm/provider-nats/internal/snapshot"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testStream = "orders"
+	testBackup = "nightly"
+)
+
+type restoreModifier func(*v1alpha1.StreamRestore)
+
+func withStream(stream string) restoreModifier {
+	return func(r *v1alpha1.StreamRestore) { r.Spec.ForProvider.Stream = stream }
+}
+
+func withSource(root string, key string) restoreModifier {
+	return func(r *v1alpha1.StreamRestore) {
+		r.Spec.ForProvider.Source = &v1alpha1.RestoreSource{
+			Target: apisv1alpha1.BackupTarget{Path: root},
+			Key:    key,
+		}
+	}
+}
+
+func withBackupRef(name string) restoreModifier {
+	return func(r *v1alpha1.StreamRestore) { r.Spec.ForProvider.BackupRef = &xpv1.Reference{Name: name} }
+}
+
+func withReplaceExisting() restoreModifier {
+	return func(r *v1alpha1.StreamRestore) { r.Spec.ForProvider.ReplaceExisting = true }
+}
+
+func withRestored() restoreModifier {
+	return func(r *v1alpha1.StreamRestore) { meta.SetExternalCreateSucceeded(r, time.Now()) }
+}
+
+// withRecorded records the source of the restored snapshot in the status.
+func withRecorded(location string) restoreModifier {
+	return func(r *v1alpha1.StreamRestore) {
+		r.Status.AtProvider.Stream = testStream
+		r.Status.AtProvider.Location = location
+	}
+}
+
+func withDeletionTimestamp() restoreModifier {
+	return func(r *v1alpha1.StreamRestore) {
+		now := metav1.Now()
+		r.SetDeletionTimestamp(&now)
+	}
+}
+
+func newRestore(name string, m ...restoreModifier) *v1alpha1.StreamRestore {
+	r := &v1alpha1.StreamRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	meta.SetExternalName(r, name)
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+// newBackup returns a StreamBackup of the test stream whose snapshot is stored
+// in root.
+func newBackup(root string, ready bool) *v1alpha1.StreamBackup {
+	b := &v1alpha1.StreamBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: testBackup},
+		Spec: v1alpha1.StreamBackupSpec{
+			ForProvider: v1alpha1.StreamBackupParameters{
+				Stream: testStream,
+				Target: apisv1alpha1.BackupTarget{Path: root},
+			},
+		},
+		Status: v1alpha1.StreamBackupStatus{
+			AtProvider: v1alpha1.StreamBackupObservation{Key: snapshot.Key(testStream, testBackup)},
+		},
+	}
+	if ready {
+		b.SetConditions(xpv1.Available())
+	}
+	return b
+}
+
+// getBackup returns a kube client that gets the supplied StreamBackup.
+func getBackup(b *v1alpha1.StreamBackup) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			b.DeepCopyInto(obj.(*v1alpha1.StreamBackup))
+			return nil
+		},
+	}
+}
+
+// newJetStream returns an empty fake JetStream after storing snapshots of the
+// test stream with 3 messages and the stream "invoices" under the key of the
+// backup "nightly" of each stream in root.
+func newJetStream(t *testing.T, root string) *fake.JetStream {
+	t.Helper()
+	ctx := context.Background()
+	js := fake.New(nats.Connection{Address: "nats://nats:4222"})
+	for _, name := range []string{testStream, "invoices"} {
+		if err := js.CreateStream(ctx, "", &natsgo.StreamConfig{Name: name}); err != nil {
+			t.Fatalf("cannot create stream: %v", err)
+		}
+		js.Stream("", name).State.Msgs = 3
+		dir := t.TempDir()
+		if err := js.BackupStream(ctx, "", name, dir); err != nil {
+			t.Fatalf("cannot back up stream: %v", err)
+		}
+		if err := snapshot.NewDirectory(root).Put(ctx, snapshot.Key(name, testBackup), dir); err != nil {
+			t.Fatalf("cannot store snapshot: %v", err)
+		}
+		if err := js.DeleteStream(ctx, "", name); err != nil {
+			t.Fatalf("cannot delete stream: %v", err)
+		}
+	}
+	return js
+}
+
+func TestObserve(t *testing.T) {
+	root := t.TempDir()
+	key := snapshot.Key(testStream, testBackup)
+
+	type args struct {
+		ctx      context.Context
+		mg       *v1alpha1.StreamRestore
+		restored bool
+	}
+
+	type want struct {
+		o          managed.ExternalObservation
+		err        error
+		messages   uint64
+		location   string
+		conditions []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotRestored": {
+			reason: "A restore without the external-create-succeeded annotation should not exist, even if the stream exists.",
+			args: args{
+				ctx:      context.Background(),
+				mg:       newRestore("restore", withStream(testStream), withSource(root, key)),
+				restored: true,
+			},
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: false},
+				conditions: []xpv1.Condition{xpv1.Unavailable()},
+			},
+		},
+		"Restored": {
+			reason: "A restore with the external-create-succeeded annotation should exist and report the restored stream.",
+			args: args{
+				ctx:      context.Background(),
+				mg:       newRestore("restore", withStream(testStream), withSource(root, key), withRestored()),
+				restored: true,
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				messages:   3,
+				location:   snapshot.NewDirectory(root).Location(key),
+				conditions: []xpv1.Condition{xpv1.Available()},
+			},
+		},
+		"BackupDeleted": {
+			reason: "A restore whose source is recorded should not resolve it again, so that its StreamBackup can be deleted.",
+			args: args{
+				ctx:      context.Background(),
+				mg:       newRestore("restore", withBackupRef(testBackup), withRestored(), withRecorded(snapshot.NewDirectory(root).Location(key))),
+				restored: true,
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				messages:   3,
+				location:   snapshot.NewDirectory(root).Location(key),
+				conditions: []xpv1.Condition{xpv1.Available()},
+			},
+		},
+		"StreamDeleted": {
+			reason: "A restore whose stream was deleted afterwards should exist but be unavailable, so it is not restored again.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newRestore("restore", withStream(testStream), withSource(root, key), withRestored()),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				conditions: []xpv1.Condition{xpv1.Unavailable().WithMessage("restored stream no longer exists")},
+			},
+		},
+		"Deleted": {
+			reason: "A deleted restore should not exist, as the restored stream is left untouched.",
+			args: args{
+				ctx:      context.Background(),
+				mg:       newRestore("restore", withStream(testStream), withSource(root, key), withRestored(), withDeletionTimestamp()),
+				restored: true,
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NoSource": {
+			reason: "A restore without backupRef or source should return an error.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newRestore("restore", withStream(testStream), withRestored()),
+			},
+			want: want{
+				err: errors.New(errNoSource),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			js := newJetStream(t, root)
+			if tc.args.restored {
+				if _, err := js.RestoreStream(tc.args.ctx, "", testStream, snapshot.NewDirectory(root).Location(key)); err != nil {
+					t.Fatalf("cannot restore stream: %v", err)
+				}
+			}
+			e := &external{
+				kube:      &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "streambackups"}, testBackup))},
+				log:       logging.NewNopLogger(),
+				newClient: js.Factory(),
+			}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.location != "" && tc.args.mg.Status.AtProvider.Location != tc.want.location {
+				t.Errorf("\n%s\ne.Observe(...): want location %s, got %s\n", tc.reason, tc.want.location, tc.args.mg.Status.AtProvider.Location)
+			}
+			if tc.args.mg.Status.AtProvider.Messages != tc.want.messages {
+				t.Errorf("\n%s\ne.Observe(...): want %d messages, got %d\n", tc.reason, tc.want.messages, tc.args.mg.Status.AtProvider.Messages)
+			}
+			for _, c := range tc.want.conditions {
+				if diff := cmp.Diff(c, tc.args.mg.GetCondition(c.Type), test.EquateConditions()); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	root := t.TempDir()
+	key := snapshot.Key(testStream, testBackup)
+	_, errNotFound := os.Open(filepath.Join(root, testStream, "hourly", "stream.tar.s2"))
+
+	// The broken snapshot passes validation, but cannot be restored.
+	broken := snapshot.Key(testStream, "broken")
+	newJetStream(t, root)
+	if err := snapshot.NewDirectory(root).Put(context.Background(), broken, snapshot.NewDirectory(root).Location(key)); err != nil {
+		t.Fatalf("cannot store snapshot: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(snapshot.NewDirectory(root).Location(broken), "stream.tar.s2"), []byte("broken"), 0600); err != nil {
+		t.Fatalf("cannot break snapshot: %v", err)
+	}
+	_, errBroken := fake.New(nats.Connection{}).RestoreStream(context.Background(), "", testStream, snapshot.NewDirectory(root).Location(broken))
+
+	type args struct {
+		ctx  context.Context
+		kube client.Client
+		mg   *v1alpha1.StreamRestore
+		// existing is the number of messages of the live stream, which
+		// does not exist if zero.
+		existing uint64
+	}
+
+	type want struct {
+		err error
+		// messages is the number of messages of the stream after the
+		// restore, which must exist if not zero.
+		messages uint64
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"RestoreSource": {
+			reason: "The snapshot of an explicit source should be restored into its stream.",
+			args: args{
+				ctx: context.Background(),
+				mg:  newRestore("restore", withStream(testStream), withSource(root, key)),
+			},
+			want: want{
+				messages: 3,
+			},
+		},
+		"RestoreBackupRef": {
+			reason: "The snapshot of a referenced StreamBackup should be restored into the stream of the backup.",
+			args: args{
+				ctx:  context.Background(),
+				kube: getBackup(newBackup(root, true)),
+				mg:   newRestore("restore", withBackupRef(testBackup)),
+			},
+			want: want{
+				messages: 3,
+			},
+		},
+		"BackupNotReady": {
+			reason: "The snapshot of a StreamBackup that is not ready should not be restored.",
+			args: args{
+				ctx:  context.Background(),
+				kube: getBackup(newBackup(root, false)),
+				mg:   newRestore("restore", withBackupRef(testBackup)),
+			},
+			want: want{
+				err: errors.Errorf(errBackupNotReady, testBackup),
+			},
+		},
+		"BackupOfOtherStream": {
+			reason: "A stream that differs from the stream of the referenced StreamBackup should be rejected before an existing stream is deleted.",
+			args: args{
+				ctx:      context.Background(),
+				kube:     getBackup(newBackup(root, true)),
+				mg:       newRestore("restore", withStream("invoices"), withBackupRef(testBackup), withReplaceExisting()),
+				existing: 7,
+			},
+			want: want{
+				err:      errors.Errorf(errStreamMismatch, "invoices", testStream, testBackup),
+				messages: 7,
+			},
+		},
+		"StreamExists": {
+			reason: "An existing stream should not be replaced unless replaceExisting is set.",
+			args: args{
+				ctx:      context.Background(),
+				mg:       newRestore("restore", withStream(testStream), withSource(root, key)),
+				existing: 7,
+			},
+			want: want{
+				err:      errors.Errorf(errStreamExists, testStream),
+				messages: 7,
+			},
+		},
+		"ReplaceExisting": {
+			reason: "An existing stream should be replaced by the snapshot if replaceExisting is set.",
+			args: args{
+				ctx:      context.Background(),
+				mg:       newRestore("restore", withStream(testStream), withSource(root, key), withReplaceExisting()),
+				existing: 7,
+			},
+			want: want{
+				messages: 3,
+			},
+		},
+		"ReplaceExistingRollback": {
+			reason: "A replaced stream should be restored again if the snapshot cannot be restored.",
+			args: args{
+				ctx:      context.Background(),
+				mg:       newRestore("restore", withStream(testStream), withSource(root, broken), withReplaceExisting()),
+				existing: 7,
+			},
+			want: want{
+				err:      errors.Wrap(errBroken, errRestore),
+				messages: 7,
+			},
+		},
+		"SnapshotOfOtherStream": {
+			reason: "A snapshot of another stream should be rejected before an existing stream is deleted.",
+			args: args{
+				ctx:      context.Background(),
+				mg:       newRestore("restore", withStream(testStream), withSource(root, snapshot.Key("invoices", testBackup)), withReplaceExisting()),
+				existing: 7,
+			},
+			want: want{
+				err:      errors.Wrap(errors.Errorf("snapshot is of stream invoices, not %s", testStream), errInvalidSnapshot),
+				messages: 7,
+			},
+		},
+		"SnapshotNotFound": {
+			reason: "A missing snapshot should be reported before an existing stream is deleted.",
+			args: args{
+				ctx:      context.Background(),
+				mg:       newRestore("restore", withStream(testStream), withSource(root, snapshot.Key(testStream, "hourly")), withReplaceExisting()),
+				existing: 7,
+			},
+			want: want{
+				err:      errors.Wrap(errNotFound, errGetSnapshot),
+				messages: 7,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			js := newJetStream(t, root)
+			if tc.args.existing > 0 {
+				if err := js.CreateStream(tc.args.ctx, "", &natsgo.StreamConfig{Name: testStream}); err != nil {
+					t.Fatalf("cannot create stream: %v", err)
+				}
+				js.Stream("", testStream).State.Msgs = tc.args.existing
+			}
+			e := &external{
+				kube:      tc.args.kube,
+				log:       logging.NewNopLogger(),
+				newClient: js.Factory(),
+			}
+			_, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			info := js.Stream("", testStream)
+			switch {
+			case tc.want.messages == 0 && info != nil:
+				t.Errorf("\n%s\ne.Create(...): want no stream, got stream with %d messages\n", tc.reason, info.State.Msgs)
+			case tc.want.messages != 0 && info == nil:
+				t.Errorf("\n%s\ne.Create(...): want stream with %d messages, got no stream\n", tc.reason, tc.want.messages)
+			case info != nil && info.State.Msgs != tc.want.messages:
+				t.Errorf("\n%s\ne.Create(...): want %d messages, got %d\n", tc.reason, tc.want.messages, info.State.Msgs)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	js := newJetStream(t, root)
+	key := snapshot.Key(testStream, testBackup)
+	if _, err := js.RestoreStream(ctx, "", testStream, snapshot.NewDirectory(root).Location(key)); err != nil {
+		t.Fatalf("cannot restore stream: %v", err)
+	}
+	e := &external{
+		log:       logging.NewNopLogger(),
+		newClient: js.Factory(),
+	}
+
+	if err := e.Delete(ctx, newRestore("restore", withStream(testStream), withSource(root, key), withRestored())); err != nil {
+		t.Errorf("e.Delete(...): unexpected error: %v", err)
+	}
+	if js.Stream("", testStream) == nil {
+		t.Errorf("e.Delete(...): want restored stream untouched, got no stream")
+	}
+}
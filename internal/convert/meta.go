@@ -0,0 +1,75 @@
+package convert
+
+import (
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DurationToString returns the string representation of a duration or an
+// empty string if the duration is nil.
+func DurationToString(d *metav1.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return d.Duration.String()
+}
+
+// StringToDuration parses a duration string. It returns nil for an empty string.
+func StringToDuration(s string) (*metav1.Duration, error) {
+	if s == "" {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.Duration{Duration: d}, nil
+}
+
+// DurationsToString joins a list of durations separated by commas.
+func DurationsToString(durations []metav1.Duration) string {
+	s := make([]string, 0, len(durations))
+	for _, d := range durations {
+		s = append(s, d.Duration.String())
+	}
+	return strings.Join(s, ",")
+}
+
+// StringToDurations parses a list of durations separated by commas.
+func StringToDurations(s string) ([]metav1.Duration, error) {
+	if s == "" {
+		return nil, nil
+	}
+	durations := []metav1.Duration{}
+	for _, part := range strings.Split(s, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		durations = append(durations, metav1.Duration{Duration: d})
+	}
+	return durations, nil
+}
+
+// MetaTimeToRFC3339 returns the RFC 3339 representation of a time or an empty
+// string if the time is nil.
+func MetaTimeToRFC3339(t *metav1.Time) (string, error) {
+	if t == nil {
+		return "", nil
+	}
+	return TimeToRFC3339(&t.Time)
+}
+
+// RFC3339ToMetaTime parses a RFC 3339 time. It returns nil for an empty string.
+func RFC3339ToMetaTime(s string) (*metav1.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := RFC3339ToTime(s)
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.Time{Time: *t}, nil
+}
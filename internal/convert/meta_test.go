@@ -0,0 +1,56 @@
+package convert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDurations(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("", DurationToString(nil))
+	assert.Equal("1h30m0s", DurationToString(&metav1.Duration{Duration: 90 * time.Minute}))
+
+	d, err := StringToDuration("")
+	assert.Nil(err)
+	assert.Nil(d)
+	d, err = StringToDuration("2m")
+	assert.Nil(err)
+	assert.Equal(2*time.Minute, d.Duration)
+	_, err = StringToDuration("two minutes")
+	assert.NotNil(err)
+
+	l, err := StringToDurations("1s, 2s,3m")
+	assert.Nil(err)
+	assert.Equal([]metav1.Duration{{Duration: time.Second}, {Duration: 2 * time.Second}, {Duration: 3 * time.Minute}}, l)
+	assert.Equal("1s,2s,3m0s", DurationsToString(l))
+	l, err = StringToDurations("")
+	assert.Nil(err)
+	assert.Nil(l)
+	_, err = StringToDurations("1s,foo")
+	assert.NotNil(err)
+}
+
+func TestMetaTime(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := MetaTimeToRFC3339(nil)
+	assert.Nil(err)
+	assert.Equal("", s)
+
+	mt, err := RFC3339ToMetaTime("2023-01-09T14:48:32Z")
+	assert.Nil(err)
+	assert.Equal(int64(1673275712), mt.Unix())
+	s, err = MetaTimeToRFC3339(mt)
+	assert.Nil(err)
+	assert.Equal("2023-01-09T14:48:32Z", s)
+
+	mt, err = RFC3339ToMetaTime("")
+	assert.Nil(err)
+	assert.Nil(mt)
+	_, err = RFC3339ToMetaTime("yesterday")
+	assert.NotNil(err)
+}
@@ -0,0 +1,81 @@
+// Package health derives health conditions of streams and consumers from
+// their last observation.
+package health
+
+import (
+	"fmt"
+	"strings"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+// DefaultMaxReplicaLag is the number of operations a replica may be behind
+// the leader if no limit is configured.
+const DefaultMaxReplicaLag uint64 = 1000
+
+// ClusterConditions returns the ReplicasHealthy and LeaderElected conditions
+// of a stream or consumer. It returns no conditions without cluster
+// information.
+func ClusterConditions(info *natsgo.ClusterInfo, maxReplicaLag *uint64) []xpv1.Condition {
+	if info == nil {
+		return nil
+	}
+
+	leader := apisv1alpha1.NoLeader()
+	if info.Leader != "" {
+		leader = apisv1alpha1.LeaderElected(info.Leader)
+	}
+
+	maxLag := DefaultMaxReplicaLag
+	if maxReplicaLag != nil {
+		maxLag = *maxReplicaLag
+	}
+	offline := []string{}
+	lagging := []string{}
+	for _, peer := range info.Replicas {
+		if peer == nil {
+			continue
+		}
+		switch {
+		case peer.Offline:
+			offline = append(offline, peer.Name)
+		case peer.Lag > maxLag:
+			lagging = append(lagging, fmt.Sprintf("%s (%d)", peer.Name, peer.Lag))
+		}
+	}
+
+	replicas := apisv1alpha1.ReplicasHealthy()
+	switch {
+	case len(offline) > 0:
+		replicas = apisv1alpha1.ReplicasUnhealthy(apisv1alpha1.ReasonReplicaOffline,
+			"Offline replicas: "+strings.Join(offline, ", "))
+	case len(lagging) > 0:
+		replicas = apisv1alpha1.ReplicasUnhealthy(apisv1alpha1.ReasonReplicaLagging,
+			fmt.Sprintf("Replicas lagging more than %d operations behind: %s", maxLag, strings.Join(lagging, ", ")))
+	}
+
+	return []xpv1.Condition{replicas, leader}
+}
+
+// BacklogCondition returns the BacklogWithinLimits condition of a consumer.
+// Limits that are not set are not checked.
+func BacklogCondition(info *natsgo.ConsumerInfo, limits *apisv1alpha1.ConsumerHealth) xpv1.Condition {
+	if limits == nil {
+		return apisv1alpha1.BacklogWithinLimits()
+	}
+	exceeded := []string{}
+	if limits.MaxPending != nil && info.NumPending > *limits.MaxPending {
+		exceeded = append(exceeded, fmt.Sprintf("%d pending messages exceed %d", info.NumPending, *limits.MaxPending))
+	}
+	if limits.MaxAckPending != nil && info.NumAckPending > *limits.MaxAckPending {
+		exceeded = append(exceeded, fmt.Sprintf("%d messages pending acknowledgement exceed %d", info.NumAckPending, *limits.MaxAckPending))
+	}
+	if len(exceeded) > 0 {
+		return apisv1alpha1.BacklogExceeded(strings.Join(exceeded, ", "))
+	}
+	return apisv1alpha1.BacklogWithinLimits()
+}
@@ -0,0 +1,97 @@
+package health
+
+import (
+	"testing"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+func TestClusterConditions(t *testing.T) {
+	maxLag := uint64(10)
+	cases := map[string]struct {
+		info          *natsgo.ClusterInfo
+		maxReplicaLag *uint64
+		replicas      corev1.ConditionStatus
+		reason        string
+		leader        corev1.ConditionStatus
+	}{
+		"Healthy": {
+			info: &natsgo.ClusterInfo{
+				Leader:   "n1",
+				Replicas: []*natsgo.PeerInfo{{Name: "n2", Current: true, Lag: 5}},
+			},
+			replicas: corev1.ConditionTrue,
+			reason:   string(apisv1alpha1.ReasonReplicasCurrent),
+			leader:   corev1.ConditionTrue,
+		},
+		"Offline": {
+			info: &natsgo.ClusterInfo{
+				Leader:   "n1",
+				Replicas: []*natsgo.PeerInfo{{Name: "n2", Offline: true}, {Name: "n3", Lag: 5000}},
+			},
+			replicas: corev1.ConditionFalse,
+			reason:   string(apisv1alpha1.ReasonReplicaOffline),
+			leader:   corev1.ConditionTrue,
+		},
+		"LaggingDefaultLimit": {
+			info: &natsgo.ClusterInfo{
+				Leader:   "n1",
+				Replicas: []*natsgo.PeerInfo{{Name: "n2", Lag: DefaultMaxReplicaLag + 1}},
+			},
+			replicas: corev1.ConditionFalse,
+			reason:   string(apisv1alpha1.ReasonReplicaLagging),
+			leader:   corev1.ConditionTrue,
+		},
+		"LaggingConfiguredLimit": {
+			info: &natsgo.ClusterInfo{
+				Leader:   "n1",
+				Replicas: []*natsgo.PeerInfo{{Name: "n2", Lag: 11}},
+			},
+			maxReplicaLag: &maxLag,
+			replicas:      corev1.ConditionFalse,
+			reason:        string(apisv1alpha1.ReasonReplicaLagging),
+			leader:        corev1.ConditionTrue,
+		},
+		"NoLeader": {
+			info:     &natsgo.ClusterInfo{},
+			replicas: corev1.ConditionTrue,
+			reason:   string(apisv1alpha1.ReasonReplicasCurrent),
+			leader:   corev1.ConditionFalse,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			conditions := ClusterConditions(tc.info, tc.maxReplicaLag)
+			assert.Len(t, conditions, 2)
+			assert.Equal(t, apisv1alpha1.TypeReplicasHealthy, conditions[0].Type)
+			assert.Equal(t, tc.replicas, conditions[0].Status)
+			assert.Equal(t, tc.reason, string(conditions[0].Reason))
+			assert.Equal(t, apisv1alpha1.TypeLeaderElected, conditions[1].Type)
+			assert.Equal(t, tc.leader, conditions[1].Status)
+		})
+	}
+
+	assert.Nil(t, ClusterConditions(nil, nil))
+}
+
+func TestBacklogCondition(t *testing.T) {
+	maxPending := uint64(100)
+	maxAckPending := 10
+	info := &natsgo.ConsumerInfo{NumPending: 50, NumAckPending: 20}
+
+	c := BacklogCondition(info, nil)
+	assert.Equal(t, corev1.ConditionTrue, c.Status)
+
+	c = BacklogCondition(info, &apisv1alpha1.ConsumerHealth{MaxPending: &maxPending})
+	assert.Equal(t, corev1.ConditionTrue, c.Status)
+
+	c = BacklogCondition(info, &apisv1alpha1.ConsumerHealth{MaxPending: &maxPending, MaxAckPending: &maxAckPending})
+	assert.Equal(t, apisv1alpha1.TypeBacklogWithinLimits, c.Type)
+	assert.Equal(t, corev1.ConditionFalse, c.Status)
+	assert.Equal(t, apisv1alpha1.ReasonLimitExceeded, c.Reason)
+	assert.Equal(t, "20 messages pending acknowledgement exceed 10", c.Message)
+}
@@ -0,0 +1,297 @@
+// Package importer generates managed resources for JetStream streams and
+// consumers that already exist, so that they can be brought under management
+// without recreating them.
+package importer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	consumerv1alpha1 "github.com/edgefarm/provider-nats/apis/consumer/v1alpha1"
+	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1/consumer"
+	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+)
+
+const (
+	errListStreams   = "cannot list streams"
+	errStreamInfo    = "cannot get info of stream %s"
+	errListConsumers = "cannot list consumers of stream %s"
+	errConsumerInfo  = "cannot get info of consumer %s of stream %s"
+	errConvert       = "cannot convert configuration of %s"
+	errDomainPrefix  = "cannot import from both a domain and an API prefix"
+	errNameCollision = "cannot generate a unique object name for %s"
+
+	maxNameLength = 253
+	// hashLength is the length of the suffix added to object names that
+	// collide with the name of another imported resource.
+	hashLength = 8
+)
+
+// Streams backing key/value and object store buckets are not imported as streams.
+var bucketStreamPrefixes = []string{"KV_", "OBJ_"}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// Options configure the generated managed resources.
+type Options struct {
+	// ProviderConfig is the name of the ProviderConfig the resources reference.
+	ProviderConfig string
+	// Domain is the JetStream domain to import from.
+	Domain string
+	// APIPrefix is the prefix of the JetStream API to import from, e.g. of
+	// another account. Cannot be combined with Domain.
+	APIPrefix string
+	// DeletionPolicy of the resources. Orphan keeps the streams and consumers
+	// when the managed resources are deleted.
+	DeletionPolicy xpv1.DeletionPolicy
+	// ManagementPolicy of the resources. ObserveOnly imports the streams and
+	// consumers without ever updating them.
+	ManagementPolicy apisv1alpha1.ManagementPolicy
+}
+
+// Result contains the managed resources of the imported streams and consumers.
+type Result struct {
+	Streams   []*streamv1alpha1.Stream
+	Consumers []*consumerv1alpha1.Consumer
+}
+
+// Import discovers all streams and their durable consumers of a domain or API
+// prefix and returns managed resources that reflect their current configuration.
+func Import(ctx context.Context, c nats.JetStream, o Options) (*Result, error) {
+	if o.Domain != "" && o.APIPrefix != "" {
+		return nil, errors.New(errDomainPrefix)
+	}
+	c = c.WithAPIPrefix(o.APIPrefix)
+
+	names, err := c.StreamList(ctx, o.Domain)
+	if err != nil {
+		return nil, errors.Wrap(err, errListStreams)
+	}
+
+	res := &Result{}
+	streamNames := objectNames{}
+	consumerNames := objectNames{}
+	for _, name := range names {
+		if isBucketStream(name) {
+			continue
+		}
+		info, err := c.StreamInfo(ctx, o.Domain, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, errStreamInfo, name)
+		}
+		if info == nil {
+			continue
+		}
+		s, err := StreamResource(info, o)
+		if err != nil {
+			return nil, err
+		}
+		if err := streamNames.unique(s, o.Domain, name); err != nil {
+			return nil, err
+		}
+		res.Streams = append(res.Streams, s)
+
+		consumers, err := c.ConsumerList(ctx, o.Domain, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, errListConsumers, name)
+		}
+		for _, consumerName := range consumers {
+			ci, err := c.ConsumerInfo(ctx, o.Domain, name, consumerName)
+			if err != nil {
+				return nil, errors.Wrapf(err, errConsumerInfo, consumerName, name)
+			}
+			// Ephemeral consumers cannot be managed
+			if ci == nil || ci.Config.Durable == "" {
+				continue
+			}
+			cr, err := ConsumerResource(ci, o)
+			if err != nil {
+				return nil, err
+			}
+			if err := consumerNames.unique(cr, o.Domain, name, ci.Config.Durable); err != nil {
+				return nil, err
+			}
+			res.Consumers = append(res.Consumers, cr)
+		}
+	}
+
+	return res, nil
+}
+
+// StreamResource returns the Stream managed resource of an existing stream.
+func StreamResource(info *natsgo.StreamInfo, o Options) (*streamv1alpha1.Stream, error) {
+	config, err := stream.ConfigNatsToV1Alpha1(&info.Config)
+	if err != nil {
+		return nil, errors.Wrapf(err, errConvert, info.Config.Name)
+	}
+
+	s := &streamv1alpha1.Stream{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: streamv1alpha1.SchemeGroupVersion.String(),
+			Kind:       streamv1alpha1.StreamKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ObjectName(o.Domain, info.Config.Name),
+		},
+		Spec: streamv1alpha1.StreamSpec{
+			ForProvider: streamv1alpha1.StreamParameters{
+				Domain:    o.Domain,
+				APIPrefix: o.APIPrefix,
+				Config:    *config,
+			},
+		},
+	}
+	setCommon(s, info.Config.Name, o)
+	s.Spec.DeletionPolicy = o.DeletionPolicy
+	s.Spec.ManagementPolicy = o.ManagementPolicy
+	return s, nil
+}
+
+// ConsumerResource returns the Consumer managed resource of an existing durable consumer.
+func ConsumerResource(info *natsgo.ConsumerInfo, o Options) (*consumerv1alpha1.Consumer, error) {
+	config, err := consumer.ConfigNatsToV1Alpha1(&info.Config)
+	if err != nil {
+		return nil, errors.Wrapf(err, errConvert, info.Name)
+	}
+
+	c := &consumerv1alpha1.Consumer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: consumerv1alpha1.SchemeGroupVersion.String(),
+			Kind:       consumerv1alpha1.ConsumerKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ObjectName(o.Domain, info.Stream, info.Config.Durable),
+		},
+		Spec: consumerv1alpha1.ConsumerSpec{
+			ForProvider: consumerv1alpha1.ConsumerParameters{
+				Stream:    info.Stream,
+				Domain:    o.Domain,
+				APIPrefix: o.APIPrefix,
+				Config:    *config,
+			},
+		},
+	}
+	setCommon(c, info.Config.Durable, o)
+	c.Spec.DeletionPolicy = o.DeletionPolicy
+	c.Spec.ManagementPolicy = o.ManagementPolicy
+	return c, nil
+}
+
+type managed interface {
+	metav1.Object
+	SetProviderConfigReference(r *xpv1.Reference)
+}
+
+func setCommon(mg managed, externalName string, o Options) {
+	meta.SetExternalName(mg, externalName)
+	mg.SetProviderConfigReference(&xpv1.Reference{Name: o.ProviderConfig})
+}
+
+// ObjectName returns a valid Kubernetes object name joining the given parts.
+func ObjectName(parts ...string) string {
+	nonEmpty := []string{}
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(strings.Join(nonEmpty, "-")), "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return strings.Trim(name, "-.")
+}
+
+// objectNames are the object names already generated for a kind.
+type objectNames map[string]bool
+
+// unique renames a resource whose object name was already generated, as
+// ObjectName maps different streams and consumers such as ORDERS and orders to
+// the same name. The renamed resource gets a suffix of the hash of the parts
+// its name was generated from.
+func (n objectNames) unique(mg metav1.Object, parts ...string) error {
+	name := mg.GetName()
+	if n[name] {
+		sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+		suffix := "-" + hex.EncodeToString(sum[:])[:hashLength]
+		if len(name) > maxNameLength-len(suffix) {
+			name = strings.Trim(name[:maxNameLength-len(suffix)], "-.")
+		}
+		name += suffix
+	}
+	if n[name] {
+		return errors.Errorf(errNameCollision, strings.Join(parts, "/"))
+	}
+	n[name] = true
+	mg.SetName(name)
+	return nil
+}
+
+func isBucketStream(name string) bool {
+	for _, prefix := range bucketStreamPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// YAML returns the managed resources of the result as a multi document YAML
+// manifest without status.
+func (r *Result) YAML() ([]byte, error) {
+	objects := []runtime.Object{}
+	for _, s := range r.Streams {
+		objects = append(objects, s)
+	}
+	for _, c := range r.Consumers {
+		objects = append(objects, c)
+	}
+
+	docs := []string{}
+	for _, obj := range objects {
+		// Round trip through JSON so that custom JSON marshalers are respected.
+		j, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		u := map[string]interface{}{}
+		d := json.NewDecoder(bytes.NewReader(j))
+		d.UseNumber()
+		if err := d.Decode(&u); err != nil {
+			return nil, err
+		}
+		delete(u, "status")
+		if m, ok := u["metadata"].(map[string]interface{}); ok {
+			delete(m, "creationTimestamp")
+		}
+		b, err := yaml.Marshal(u)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, string(b))
+	}
+	return []byte(strings.Join(docs, "---\n")), nil
+}
+
+// String returns a summary of the result.
+func (r *Result) String() string {
+	return fmt.Sprintf("%d streams, %d consumers", len(r.Streams), len(r.Consumers))
+}
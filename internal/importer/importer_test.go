@@ -0,0 +1,193 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	consumerv1alpha1 "github.com/edgefarm/provider-nats/apis/consumer/v1alpha1"
+	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+	apisv1alpha1 "github.com/edgefarm/provider-nats/apis/v1alpha1"
+	nats "github.com/edgefarm/provider-nats/internal/clients/nats"
+	"github.com/edgefarm/provider-nats/internal/clients/nats/fake"
+)
+
+func TestObjectName(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("mystream", ObjectName("", "mystream"))
+	assert.Equal("mydomain-my-stream", ObjectName("mydomain", "My_Stream"))
+	assert.Equal("mydomain-orders-processor", ObjectName("mydomain", "ORDERS", "processor"))
+	assert.Equal("stream", ObjectName("_stream_"))
+	assert.Len(ObjectName(strings.Repeat("a", 300)), maxNameLength)
+}
+
+func TestStreamResource(t *testing.T) {
+	assert := assert.New(t)
+	o := Options{ProviderConfig: "default", Domain: "mydomain", DeletionPolicy: xpv1.DeletionOrphan, ManagementPolicy: apisv1alpha1.ManagementObserveOnly}
+	info := &natsgo.StreamInfo{
+		Config: natsgo.StreamConfig{
+			Name:      "ORDERS",
+			Subjects:  []string{"orders.>"},
+			Retention: natsgo.LimitsPolicy,
+			Storage:   natsgo.FileStorage,
+			Discard:   natsgo.DiscardOld,
+			MaxAge:    time.Hour,
+			Replicas:  1,
+		},
+	}
+
+	s, err := StreamResource(info, o)
+	assert.Nil(err)
+	assert.Equal("mydomain-orders", s.Name)
+	assert.Equal("ORDERS", meta.GetExternalName(s))
+	assert.Equal("default", s.GetProviderConfigReference().Name)
+	assert.Equal(xpv1.DeletionOrphan, s.GetDeletionPolicy())
+	assert.Equal(apisv1alpha1.ManagementObserveOnly, s.Spec.ManagementPolicy)
+	assert.Equal("mydomain", s.Spec.ForProvider.Domain)
+	assert.Equal([]string{"orders.>"}, s.Spec.ForProvider.Config.Subjects)
+	assert.Equal("1h0m0s", s.Spec.ForProvider.Config.MaxAge)
+}
+
+func TestConsumerResource(t *testing.T) {
+	assert := assert.New(t)
+	o := Options{ProviderConfig: "default", DeletionPolicy: xpv1.DeletionOrphan, ManagementPolicy: apisv1alpha1.ManagementObserveOnly}
+	info := &natsgo.ConsumerInfo{
+		Stream: "ORDERS",
+		Name:   "processor",
+		Config: natsgo.ConsumerConfig{
+			Durable:       "processor",
+			DeliverPolicy: natsgo.DeliverAllPolicy,
+			AckPolicy:     natsgo.AckExplicitPolicy,
+			AckWait:       30 * time.Second,
+			ReplayPolicy:  natsgo.ReplayInstantPolicy,
+			MaxWaiting:    512,
+		},
+	}
+
+	c, err := ConsumerResource(info, o)
+	assert.Nil(err)
+	assert.Equal("orders-processor", c.Name)
+	assert.Equal("processor", meta.GetExternalName(c))
+	assert.Equal("ORDERS", c.Spec.ForProvider.Stream)
+	assert.Equal("default", c.GetProviderConfigReference().Name)
+	assert.Equal(apisv1alpha1.ManagementObserveOnly, c.Spec.ManagementPolicy)
+	assert.NotNil(c.Spec.ForProvider.Config.PullConsumer.MaxWaiting)
+	assert.Nil(c.Spec.ForProvider.Config.PushConsumer)
+}
+
+func TestResultYAML(t *testing.T) {
+	assert := assert.New(t)
+	o := Options{ProviderConfig: "default", DeletionPolicy: xpv1.DeletionOrphan}
+	s, err := StreamResource(&natsgo.StreamInfo{Config: natsgo.StreamConfig{Name: "ORDERS"}}, o)
+	assert.Nil(err)
+	c, err := ConsumerResource(&natsgo.ConsumerInfo{Stream: "ORDERS", Config: natsgo.ConsumerConfig{Durable: "processor"}}, o)
+	assert.Nil(err)
+
+	out, err := (&Result{Streams: []*streamv1alpha1.Stream{s}, Consumers: []*consumerv1alpha1.Consumer{c}}).YAML()
+	assert.Nil(err)
+	docs := strings.Split(string(out), "---\n")
+	assert.Len(docs, 2)
+	assert.Contains(docs[0], "kind: Stream")
+	assert.Contains(docs[0], "crossplane.io/external-name: ORDERS")
+	assert.Contains(docs[1], "kind: Consumer")
+	assert.NotContains(string(out), "status:")
+	assert.NotContains(string(out), "creationTimestamp")
+}
+
+func TestIsBucketStream(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(isBucketStream("KV_config"))
+	assert.True(isBucketStream("OBJ_files"))
+	assert.False(isBucketStream("ORDERS"))
+}
+
+func TestImport(t *testing.T) {
+	assert := assert.New(t)
+	js := fake.New(nats.Connection{})
+	assert.Nil(js.CreateStream(context.Background(), "mydomain", &natsgo.StreamConfig{Name: "ORDERS", Subjects: []string{"orders.>"}}))
+	assert.Nil(js.CreateStream(context.Background(), "mydomain", &natsgo.StreamConfig{Name: "KV_config"}))
+	assert.Nil(js.CreateStream(context.Background(), "", &natsgo.StreamConfig{Name: "OTHER"}))
+	assert.Nil(js.CreateConsumer(context.Background(), "mydomain", "ORDERS", &natsgo.ConsumerConfig{Durable: "processor", AckPolicy: natsgo.AckExplicitPolicy}))
+
+	res, err := Import(context.Background(), js, Options{ProviderConfig: "default", Domain: "mydomain"})
+	assert.Nil(err)
+	assert.Len(res.Streams, 1)
+	assert.Equal("ORDERS", meta.GetExternalName(res.Streams[0]))
+	assert.Len(res.Consumers, 1)
+	assert.Equal("processor", meta.GetExternalName(res.Consumers[0]))
+	assert.Equal("ORDERS", res.Consumers[0].Spec.ForProvider.Stream)
+}
+
+func TestImportAPIPrefix(t *testing.T) {
+	assert := assert.New(t)
+	js := fake.New(nats.Connection{})
+	tenant := js.WithAPIPrefix("$JS.tenant.API")
+	assert.Nil(tenant.CreateStream(context.Background(), "", &natsgo.StreamConfig{Name: "ORDERS"}))
+	assert.Nil(tenant.CreateConsumer(context.Background(), "", "ORDERS", &natsgo.ConsumerConfig{Durable: "processor", AckPolicy: natsgo.AckExplicitPolicy}))
+	assert.Nil(js.CreateStream(context.Background(), "", &natsgo.StreamConfig{Name: "OTHER"}))
+
+	res, err := Import(context.Background(), js, Options{ProviderConfig: "default", APIPrefix: "$JS.tenant.API"})
+	assert.Nil(err)
+	assert.Len(res.Streams, 1)
+	assert.Equal("ORDERS", meta.GetExternalName(res.Streams[0]))
+	assert.Equal("$JS.tenant.API", res.Streams[0].Spec.ForProvider.APIPrefix)
+	assert.Len(res.Consumers, 1)
+	assert.Equal("$JS.tenant.API", res.Consumers[0].Spec.ForProvider.APIPrefix)
+
+	_, err = Import(context.Background(), js, Options{Domain: "mydomain", APIPrefix: "$JS.tenant.API"})
+	assert.NotNil(err)
+}
+
+func TestImportNameCollision(t *testing.T) {
+	assert := assert.New(t)
+	js := fake.New(nats.Connection{})
+	for _, name := range []string{"ORDERS", "orders", "a_b", "a-b", "a"} {
+		assert.Nil(js.CreateStream(context.Background(), "", &natsgo.StreamConfig{Name: name}))
+	}
+	assert.Nil(js.CreateConsumer(context.Background(), "", "a-b", &natsgo.ConsumerConfig{Durable: "c", AckPolicy: natsgo.AckExplicitPolicy}))
+	assert.Nil(js.CreateConsumer(context.Background(), "", "a", &natsgo.ConsumerConfig{Durable: "b-c", AckPolicy: natsgo.AckExplicitPolicy}))
+
+	res, err := Import(context.Background(), js, Options{ProviderConfig: "default"})
+	assert.Nil(err)
+	names := map[string]string{}
+	for _, s := range res.Streams {
+		assert.LessOrEqual(len(s.Name), maxNameLength)
+		assert.NotContains(names, s.Name)
+		names[s.Name] = meta.GetExternalName(s)
+	}
+	assert.Len(names, 5)
+	names = map[string]string{}
+	for _, c := range res.Consumers {
+		assert.NotContains(names, c.Name)
+		names[c.Name] = c.Spec.ForProvider.Stream
+	}
+	assert.Len(names, 2)
+}
+
+func TestObjectNamesUnique(t *testing.T) {
+	assert := assert.New(t)
+	n := objectNames{}
+	long := strings.Repeat("a", 300)
+	first := &streamv1alpha1.Stream{}
+	first.SetName(ObjectName(long))
+	assert.Nil(n.unique(first, long))
+	assert.Equal(ObjectName(long), first.Name)
+
+	second := &streamv1alpha1.Stream{}
+	second.SetName(ObjectName(strings.ToUpper(long)))
+	assert.Nil(n.unique(second, strings.ToUpper(long)))
+	assert.NotEqual(first.Name, second.Name)
+	assert.Len(second.Name, maxNameLength)
+
+	// The same parts can only be imported once.
+	third := &streamv1alpha1.Stream{}
+	third.SetName(ObjectName(strings.ToUpper(long)))
+	assert.NotNil(n.unique(third, strings.ToUpper(long)))
+}
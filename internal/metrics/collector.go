@@ -0,0 +1,138 @@
+// Package metrics exports the state of streams and consumers as last observed
+// by their controllers as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	consumerv1alpha1 "github.com/edgefarm/provider-nats/apis/consumer/v1alpha1"
+	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+)
+
+const listTimeout = 10 * time.Second
+
+var (
+	streamLabels   = []string{"name", "stream", "domain"}
+	consumerLabels = []string{"name", "consumer", "stream", "domain"}
+
+	streamMessages = prometheus.NewDesc("provider_nats_stream_messages",
+		"Number of messages in the stream.", streamLabels, nil)
+	streamBytes = prometheus.NewDesc("provider_nats_stream_bytes",
+		"Number of bytes in the stream.", streamLabels, nil)
+	streamConsumers = prometheus.NewDesc("provider_nats_stream_consumers",
+		"Number of consumers of the stream.", streamLabels, nil)
+	streamLastSequence = prometheus.NewDesc("provider_nats_stream_last_sequence",
+		"Last sequence number of the stream.", streamLabels, nil)
+	streamReplicaLag = prometheus.NewDesc("provider_nats_stream_replica_lag",
+		"Number of operations a replica of the stream is behind the leader.", append(streamLabels, "peer"), nil)
+
+	consumerPending = prometheus.NewDesc("provider_nats_consumer_pending_messages",
+		"Number of messages of the stream the consumer has not delivered yet.", consumerLabels, nil)
+	consumerAckPending = prometheus.NewDesc("provider_nats_consumer_ack_pending_messages",
+		"Number of delivered messages waiting for an acknowledgement.", consumerLabels, nil)
+	consumerRedelivered = prometheus.NewDesc("provider_nats_consumer_redelivered_messages",
+		"Number of messages that were redelivered.", consumerLabels, nil)
+	consumerWaiting = prometheus.NewDesc("provider_nats_consumer_waiting_requests",
+		"Number of waiting pull requests.", consumerLabels, nil)
+	consumerReplicaLag = prometheus.NewDesc("provider_nats_consumer_replica_lag",
+		"Number of operations a replica of the consumer is behind the leader.", append(consumerLabels, "peer"), nil)
+
+	collectErrors = prometheus.NewDesc("provider_nats_collect_errors",
+		"1 if the streams or consumers could not be listed during the last scrape.", []string{"kind"}, nil)
+)
+
+// Collector collects the state of all streams and consumers from their status
+// at scrape time, so that metrics of deleted resources disappear with them.
+type Collector struct {
+	kube client.Reader
+}
+
+// NewCollector returns a collector that lists streams and consumers with the
+// given reader, usually the cached client of the manager.
+func NewCollector(kube client.Reader) *Collector {
+	return &Collector{kube: kube}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		streamMessages, streamBytes, streamConsumers, streamLastSequence, streamReplicaLag,
+		consumerPending, consumerAckPending, consumerRedelivered, consumerWaiting, consumerReplicaLag,
+		collectErrors,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), listTimeout)
+	defer cancel()
+
+	streams := &streamv1alpha1.StreamList{}
+	if err := c.kube.List(ctx, streams); err != nil {
+		ch <- prometheus.MustNewConstMetric(collectErrors, prometheus.GaugeValue, 1, streamv1alpha1.StreamKind)
+	} else {
+		ch <- prometheus.MustNewConstMetric(collectErrors, prometheus.GaugeValue, 0, streamv1alpha1.StreamKind)
+		for i := range streams.Items {
+			collectStream(ch, &streams.Items[i])
+		}
+	}
+
+	consumers := &consumerv1alpha1.ConsumerList{}
+	if err := c.kube.List(ctx, consumers); err != nil {
+		ch <- prometheus.MustNewConstMetric(collectErrors, prometheus.GaugeValue, 1, consumerv1alpha1.ConsumerKind)
+	} else {
+		ch <- prometheus.MustNewConstMetric(collectErrors, prometheus.GaugeValue, 0, consumerv1alpha1.ConsumerKind)
+		for i := range consumers.Items {
+			collectConsumer(ch, &consumers.Items[i])
+		}
+	}
+}
+
+func collectStream(ch chan<- prometheus.Metric, s *streamv1alpha1.Stream) {
+	at := s.Status.AtProvider
+	// The stream was not observed yet.
+	if at.Connection.Address == "" {
+		return
+	}
+	labels := []string{s.GetName(), meta.GetExternalName(s), at.Domain}
+	ch <- prometheus.MustNewConstMetric(streamMessages, prometheus.GaugeValue, float64(at.State.Messages), labels...)
+	ch <- prometheus.MustNewConstMetric(streamBytes, prometheus.GaugeValue, float64(at.State.NumBytes), labels...)
+	ch <- prometheus.MustNewConstMetric(streamConsumers, prometheus.GaugeValue, float64(at.State.ConsumerCount), labels...)
+	ch <- prometheus.MustNewConstMetric(streamLastSequence, prometheus.GaugeValue, float64(at.State.LastSequence), labels...)
+	for _, peer := range at.ClusterInfo.Replicas {
+		if peer == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(streamReplicaLag, prometheus.GaugeValue, float64(peer.Lag), append(labels, peer.Name)...)
+	}
+}
+
+func collectConsumer(ch chan<- prometheus.Metric, c *consumerv1alpha1.Consumer) {
+	state := c.Status.AtProvider.State
+	// The consumer was not observed yet.
+	if state.Stream == "" {
+		return
+	}
+	labels := []string{c.GetName(), meta.GetExternalName(c), state.Stream, state.Domain}
+	ch <- prometheus.MustNewConstMetric(consumerPending, prometheus.GaugeValue, float64(state.NumPending), labels...)
+	ch <- prometheus.MustNewConstMetric(consumerAckPending, prometheus.GaugeValue, float64(state.NumAckPending), labels...)
+	ch <- prometheus.MustNewConstMetric(consumerRedelivered, prometheus.GaugeValue, float64(state.NumRedelivered), labels...)
+	ch <- prometheus.MustNewConstMetric(consumerWaiting, prometheus.GaugeValue, float64(state.NumWaiting), labels...)
+	if state.Cluster == nil {
+		return
+	}
+	for _, peer := range state.Cluster.Replicas {
+		if peer == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(consumerReplicaLag, prometheus.GaugeValue, float64(peer.Lag), append(labels, peer.Name)...)
+	}
+}
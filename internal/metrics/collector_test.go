@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	consumerv1alpha1 "github.com/edgefarm/provider-nats/apis/consumer/v1alpha1"
+	"github.com/edgefarm/provider-nats/apis/consumer/v1alpha1/consumer"
+	streamv1alpha1 "github.com/edgefarm/provider-nats/apis/stream/v1alpha1"
+	"github.com/edgefarm/provider-nats/apis/stream/v1alpha1/stream"
+)
+
+func TestCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &streamv1alpha1.Stream{ObjectMeta: metav1.ObjectMeta{Name: "orders"}}
+	meta.SetExternalName(s, "ORDERS")
+	s.Status.AtProvider.Domain = "leaf"
+	s.Status.AtProvider.Connection.Address = "nats://localhost:4222"
+	s.Status.AtProvider.State = stream.StreamObservationState{
+		Messages:      10,
+		NumBytes:      2048,
+		LastSequence:  12,
+		ConsumerCount: 1,
+	}
+	s.Status.AtProvider.ClusterInfo.Replicas = []*stream.PeerInfo{{Name: "n2", Lag: 3}}
+
+	// Streams that were not observed yet are skipped.
+	pending := &streamv1alpha1.Stream{ObjectMeta: metav1.ObjectMeta{Name: "pending"}}
+
+	c := &consumerv1alpha1.Consumer{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	meta.SetExternalName(c, "WORKER")
+	c.Status.AtProvider.State = consumer.ConsumerObservationState{
+		Domain:        "leaf",
+		Stream:        "ORDERS",
+		NumPending:    7,
+		NumAckPending: 2,
+	}
+
+	scheme := runtime.NewScheme()
+	assert.Nil(streamv1alpha1.SchemeBuilder.AddToScheme(scheme))
+	assert.Nil(consumerv1alpha1.SchemeBuilder.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(s, pending, c).Build()
+
+	expected := `
+# HELP provider_nats_consumer_ack_pending_messages Number of delivered messages waiting for an acknowledgement.
+# TYPE provider_nats_consumer_ack_pending_messages gauge
+provider_nats_consumer_ack_pending_messages{consumer="WORKER",domain="leaf",name="worker",stream="ORDERS"} 2
+# HELP provider_nats_consumer_pending_messages Number of messages of the stream the consumer has not delivered yet.
+# TYPE provider_nats_consumer_pending_messages gauge
+provider_nats_consumer_pending_messages{consumer="WORKER",domain="leaf",name="worker",stream="ORDERS"} 7
+# HELP provider_nats_stream_bytes Number of bytes in the stream.
+# TYPE provider_nats_stream_bytes gauge
+provider_nats_stream_bytes{domain="leaf",name="orders",stream="ORDERS"} 2048
+# HELP provider_nats_stream_messages Number of messages in the stream.
+# TYPE provider_nats_stream_messages gauge
+provider_nats_stream_messages{domain="leaf",name="orders",stream="ORDERS"} 10
+# HELP provider_nats_stream_replica_lag Number of operations a replica of the stream is behind the leader.
+# TYPE provider_nats_stream_replica_lag gauge
+provider_nats_stream_replica_lag{domain="leaf",name="orders",peer="n2",stream="ORDERS"} 3
+`
+	assert.Nil(testutil.CollectAndCompare(NewCollector(kube), strings.NewReader(expected),
+		"provider_nats_consumer_ack_pending_messages",
+		"provider_nats_consumer_pending_messages",
+		"provider_nats_stream_bytes",
+		"provider_nats_stream_messages",
+		"provider_nats_stream_replica_lag",
+	))
+}
+
+func TestCollectorListError(t *testing.T) {
+	// The scheme does not know the types, so listing fails.
+	kube := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	expected := `
+# HELP provider_nats_collect_errors 1 if the streams or consumers could not be listed during the last scrape.
+# TYPE provider_nats_collect_errors gauge
+provider_nats_collect_errors{kind="Consumer"} 1
+provider_nats_collect_errors{kind="Stream"} 1
+`
+	assert.Nil(t, testutil.CollectAndCompare(NewCollector(kube), strings.NewReader(expected), "provider_nats_collect_errors"))
+}
@@ -0,0 +1,107 @@
+package snapshot
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Directory stores snapshots in <root>/<key>, e.g. on a mounted volume.
+type Directory struct {
+	root string
+}
+
+// NewDirectory returns a store for snapshots below root.
+func NewDirectory(root string) *Directory {
+	return &Directory{root: root}
+}
+
+// Location returns the directory of the snapshot stored under key.
+func (d *Directory) Location(key string) string {
+	return filepath.Join(d.root, filepath.FromSlash(key))
+}
+
+// Put copies the snapshot in dir to the directory of key.
+func (d *Directory) Put(ctx context.Context, key string, dir string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	dst := d.Location(key)
+	if err := os.MkdirAll(dst, 0700); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := copyFile(filepath.Join(dir, f), filepath.Join(dst, f)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get copies the snapshot in the directory of key to dir.
+func (d *Directory) Get(ctx context.Context, key string, dir string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	src := d.Location(key)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := copyFile(filepath.Join(src, f), filepath.Join(dir, f)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stat returns the snapshot in the directory of key or nil if it is incomplete
+// or does not exist.
+func (d *Directory) Stat(ctx context.Context, key string) (*Info, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	loc := d.Location(key)
+	info := &Info{Location: loc}
+	for _, f := range files {
+		fi, err := os.Stat(filepath.Join(loc, f))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		info.Size += fi.Size()
+		if f == metaFile {
+			info.Completed = fi.ModTime()
+		}
+	}
+	return info, nil
+}
+
+// Delete removes the directory of key.
+func (d *Directory) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return os.RemoveAll(d.Location(key))
+}
+
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
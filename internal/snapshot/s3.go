@@ -0,0 +1,114 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+// S3 stores snapshots as objects below <prefix>/<key> in a bucket of an
+// S3-compatible object storage.
+type S3 struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3 returns a store for snapshots in the bucket of target. The bucket is
+// accessed anonymously if accessKey is empty.
+func NewS3(target *v1alpha1.S3Target, accessKey string, secretKey string) (*S3, error) {
+	client, err := minio.New(target.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: !target.Insecure,
+		Region: target.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &S3{client: client, bucket: target.Bucket, prefix: target.Prefix}, nil
+}
+
+func (s *S3) object(key string, file string) string {
+	return path.Join(s.prefix, key, file)
+}
+
+// Location returns the URL of the snapshot stored under key.
+func (s *S3) Location(key string) string {
+	return fmt.Sprintf("s3://%s/%s", s.bucket, path.Join(s.prefix, key))
+}
+
+// Put uploads the snapshot in dir to the objects of key.
+func (s *S3) Put(ctx context.Context, key string, dir string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	for _, f := range files {
+		_, err := s.client.FPutObject(ctx, s.bucket, s.object(key, f), filepath.Join(dir, f), minio.PutObjectOptions{
+			ContentType: "application/octet-stream",
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get downloads the snapshot stored in the objects of key to dir.
+func (s *S3) Get(ctx context.Context, key string, dir string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := s.client.FGetObject(ctx, s.bucket, s.object(key, f), filepath.Join(dir, f), minio.GetObjectOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stat returns the snapshot stored in the objects of key or nil if it is
+// incomplete or does not exist.
+func (s *S3) Stat(ctx context.Context, key string) (*Info, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	info := &Info{Location: s.Location(key)}
+	for _, f := range files {
+		obj, err := s.client.StatObject(ctx, s.bucket, s.object(key, f), minio.StatObjectOptions{})
+		if minio.ToErrorResponse(err).StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		info.Size += obj.Size
+		if f == metaFile {
+			info.Completed = obj.LastModified
+		}
+	}
+	return info, nil
+}
+
+// Delete removes the objects of key.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := s.client.RemoveObject(ctx, s.bucket, s.object(key, f), minio.RemoveObjectOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
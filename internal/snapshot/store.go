@@ -0,0 +1,134 @@
+// Package snapshot stores snapshots of JetStream streams in a directory or in
+// an S3-compatible object storage.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/nats-io/jsm.go/api"
+
+	"github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+const (
+	errInvalidKey      = "invalid snapshot key %q"
+	errGetAccessKey    = "cannot get access key ID"
+	errGetSecretKey    = "cannot get secret access key"
+	errIncompleteCreds = "both accessKeyIdSecretRef and secretAccessKeySecretRef must be set"
+	errIncomplete      = "snapshot is incomplete"
+	errReadMeta        = "cannot read snapshot metadata"
+	errStreamMismatch  = "snapshot is of stream %s, not %s"
+	errMemoryStream    = "snapshots of memory streams cannot be restored"
+)
+
+// Files a snapshot consists of. The metadata file is stored last, so its
+// presence marks a complete snapshot.
+const (
+	dataFile = "stream.tar.s2"
+	metaFile = "backup.json"
+)
+
+var files = []string{dataFile, metaFile}
+
+// Info describes a stored snapshot.
+type Info struct {
+	// Location is the directory or URL the snapshot is stored at.
+	Location string
+	// Size is the size of all files of the snapshot in bytes.
+	Size int64
+	// Completed is the time the snapshot was stored.
+	Completed time.Time
+}
+
+// A Store stores snapshots under a key.
+type Store interface {
+	// Put stores the snapshot in dir under key.
+	Put(ctx context.Context, key string, dir string) error
+	// Get writes the snapshot stored under key into dir.
+	Get(ctx context.Context, key string, dir string) error
+	// Stat returns the snapshot stored under key or nil if there is none.
+	Stat(ctx context.Context, key string) (*Info, error)
+	// Delete removes the snapshot stored under key.
+	Delete(ctx context.Context, key string) error
+	// Location returns the directory or URL of the snapshot stored under key.
+	Location(key string) string
+}
+
+// Key returns the key of the snapshot of a stream taken by a backup.
+func Key(stream string, backup string) string {
+	return path.Join(stream, backup)
+}
+
+// validateKey rejects keys that would escape the root of a store.
+func validateKey(key string) error {
+	clean := path.Clean(key)
+	if key == "" || path.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return errors.Errorf(errInvalidKey, key)
+	}
+	return nil
+}
+
+// Validate checks that dir holds a complete snapshot of stream that can be
+// restored. JetStream restores a snapshot only under the name of the stream it
+// was taken of.
+func Validate(dir string, stream string) error {
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			return errors.Wrap(err, errIncomplete)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(dir, metaFile))
+	if err != nil {
+		return errors.Wrap(err, errReadMeta)
+	}
+	meta := api.JSApiStreamRestoreRequest{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return errors.Wrap(err, errReadMeta)
+	}
+	if meta.Config.Name != stream {
+		return errors.Errorf(errStreamMismatch, meta.Config.Name, stream)
+	}
+	if meta.Config.Storage == api.MemoryStorage {
+		return errors.New(errMemoryStream)
+	}
+	return nil
+}
+
+// New returns the store of a backup target. The credentials of S3 targets are
+// read from the referenced secrets.
+func New(ctx context.Context, kube client.Client, target *v1alpha1.BackupTarget) (Store, error) {
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+	if target.Path != "" {
+		return NewDirectory(target.Path), nil
+	}
+
+	s3 := target.S3
+	if (s3.AccessKeyIDSecretRef == nil) != (s3.SecretAccessKeySecretRef == nil) {
+		return nil, errors.New(errIncompleteCreds)
+	}
+	if s3.AccessKeyIDSecretRef == nil {
+		return NewS3(s3, "", "")
+	}
+	accessKey, err := resource.ExtractSecret(ctx, kube, xpv1.CommonCredentialSelectors{SecretRef: s3.AccessKeyIDSecretRef})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetAccessKey)
+	}
+	secretKey, err := resource.ExtractSecret(ctx, kube, xpv1.CommonCredentialSelectors{SecretRef: s3.SecretAccessKeySecretRef})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetSecretKey)
+	}
+	return NewS3(s3, string(accessKey), string(secretKey))
+}
@@ -0,0 +1,107 @@
+package snapshot
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgefarm/provider-nats/apis/v1alpha1"
+)
+
+func TestKey(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("orders/nightly", Key("orders", "nightly"))
+}
+
+func TestValidateKey(t *testing.T) {
+	assert := assert.New(t)
+	for _, key := range []string{"orders/nightly", "orders", "orders/../nightly"} {
+		assert.Nil(validateKey(key), key)
+	}
+	for _, key := range []string{"", ".", "..", "../orders", "/orders", "orders/../../nightly"} {
+		assert.Error(validateKey(key), key)
+	}
+}
+
+func TestDirectory(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	src := t.TempDir()
+	assert.Nil(os.WriteFile(filepath.Join(src, dataFile), []byte("data"), 0600))
+	assert.Nil(os.WriteFile(filepath.Join(src, metaFile), []byte("{}"), 0600))
+
+	d := NewDirectory(t.TempDir())
+	key := Key("orders", "nightly")
+
+	info, err := d.Stat(ctx, key)
+	assert.Nil(err)
+	assert.Nil(info)
+
+	assert.Nil(d.Put(ctx, key, src))
+	info, err = d.Stat(ctx, key)
+	assert.Nil(err)
+	assert.NotNil(info)
+	assert.Equal(d.Location(key), info.Location)
+	assert.Equal(int64(6), info.Size)
+	assert.False(info.Completed.IsZero())
+
+	dst := filepath.Join(t.TempDir(), "restore")
+	assert.Nil(d.Get(ctx, key, dst))
+	data, err := os.ReadFile(filepath.Join(dst, dataFile))
+	assert.Nil(err)
+	assert.Equal("data", string(data))
+
+	assert.Nil(d.Delete(ctx, key))
+	info, err = d.Stat(ctx, key)
+	assert.Nil(err)
+	assert.Nil(info)
+}
+
+func TestDirectoryIncomplete(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	d := NewDirectory(t.TempDir())
+	key := Key("orders", "nightly")
+	assert.Nil(os.MkdirAll(d.Location(key), 0700))
+	assert.Nil(os.WriteFile(filepath.Join(d.Location(key), dataFile), []byte("data"), 0600))
+
+	info, err := d.Stat(ctx, key)
+	assert.Nil(err)
+	assert.Nil(info)
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	assert.Error(Validate(dir, "orders"))
+
+	assert.Nil(os.WriteFile(filepath.Join(dir, dataFile), []byte("data"), 0600))
+	assert.Nil(os.WriteFile(filepath.Join(dir, metaFile), []byte(`{"config":{"name":"orders","storage":"file"}}`), 0600))
+	assert.Nil(Validate(dir, "orders"))
+	assert.EqualError(Validate(dir, "invoices"), "snapshot is of stream orders, not invoices")
+
+	assert.Nil(os.WriteFile(filepath.Join(dir, metaFile), []byte(`{"config":{"name":"orders","storage":"memory"}}`), 0600))
+	assert.EqualError(Validate(dir, "orders"), errMemoryStream)
+
+	assert.Nil(os.WriteFile(filepath.Join(dir, metaFile), []byte("{"), 0600))
+	assert.Error(Validate(dir, "orders"))
+}
+
+func TestNew(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	_, err := New(ctx, nil, &v1alpha1.BackupTarget{})
+	assert.Error(err)
+
+	s, err := New(ctx, nil, &v1alpha1.BackupTarget{Path: "/backups"})
+	assert.Nil(err)
+	assert.Equal("/backups/orders/nightly", s.Location(Key("orders", "nightly")))
+
+	s, err = New(ctx, nil, &v1alpha1.BackupTarget{S3: &v1alpha1.S3Target{Endpoint: "minio:9000", Bucket: "backups", Prefix: "edge"}})
+	assert.Nil(err)
+	assert.Equal("s3://backups/edge/orders/nightly", s.Location(Key("orders", "nightly")))
+}